@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/Morras/go-neutrino/game"
+)
+
+const (
+	//The deepest a search without a depth limit goes
+	maxSearchDepth = 64
+
+	//How many moves are made between checks of the context
+	contextCheckInterval = 256
+)
+
+/**
+ * When Search should stop, a zero field is no limit. The
+ * search always stops after maxSearchDepth moves.
+ */
+type Limits struct {
+	//The number of moves to search ahead at most
+	Depth int
+	//The wall clock time to search for at most
+	Time time.Duration
+	//The number of moves to make while searching at most
+	Nodes int
+}
+
+type Result struct {
+	Move game.Move
+	//The score of Move from the point of view of the player
+	//to move, only reliable when Depth is above 0
+	Score int
+	//The depth of the deepest complete search, 0 if the search
+	//stopped before the first depth was done
+	Depth int
+	//The number of moves made while searching
+	Nodes int
+	//The principal variation, the moves expected to be played
+	//starting with Move
+	PV []game.Move
+}
+
+//Stops a search on a node budget or when the context is done
+type stopCondition struct {
+	ctx   context.Context
+	nodes int
+}
+
+/**
+ * Searches one move deeper at a time until the depth, time
+ * or node budget of the limits is used or the context is
+ * done, and returns the result of the deepest complete
+ * search. If the search is stopped before the first depth
+ * is done the best move found so far is returned, and it
+ * is at least a legal move. Stopping is not an error.
+ */
+func (self *Searcher) Search(ctx context.Context, g *game.Game, limits Limits) (Result, error) {
+	search, err := self.newSearch(g)
+	if err != nil {
+		return Result{}, err
+	}
+	if limits.Time > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Time)
+		defer cancel()
+	}
+	search.stop = stopCondition{ctx: ctx, nodes: limits.Nodes}
+
+	maxDepth := limits.Depth
+	if maxDepth <= 0 || maxDepth > maxSearchDepth {
+		maxDepth = maxSearchDepth
+	}
+
+	var result Result
+	for depth := 1; depth <= maxDepth; depth++ {
+		move, score, complete := search.root(depth, result.Move)
+		if !complete {
+			if result.Depth == 0 && move != (game.Move{}) {
+				result = Result{Move: move, Score: score, PV: search.copyPV()}
+			}
+			break
+		}
+		result = Result{Move: move, Score: score, Depth: depth, PV: search.copyPV()}
+		//A win or a loss within the depth will not change by searching deeper
+		if score >= WinScore-depth || score <= -WinScore+depth {
+			break
+		}
+	}
+
+	if result.Move == (game.Move{}) {
+		//Stopped before the first move was scored
+		result.Move = search.orderedMoves(search.game.State.Player())[0]
+		result.PV = []game.Move{result.Move}
+	}
+	result.Nodes = search.nodes
+	return result, nil
+}
+
+func (self *search) copyPV() []game.Move {
+	return append([]game.Move(nil), self.pv[0]...)
+}
+
+/**
+ * Counts a move made by the search and returns true if
+ * the search should stop. The context is only checked
+ * every contextCheckInterval moves as it is slow.
+ */
+func (self *search) countNode() bool {
+	if self.stopped {
+		return true
+	}
+	if self.stop.nodes > 0 && self.nodes >= self.stop.nodes {
+		self.stopped = true
+		return true
+	}
+	if self.stop.ctx != nil && self.nodes%contextCheckInterval == 0 && self.stop.ctx.Err() != nil {
+		self.stopped = true
+		return true
+	}
+	self.nodes++
+	return false
+}
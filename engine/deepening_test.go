@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Morras/go-neutrino/game"
+)
+
+func isLegalMove(g *game.Game, move game.Move) bool {
+	controller := game.NewController(game.StandardRules())
+	copyOfGame := *g
+	controller.PlayGame(&copyOfGame)
+	_, err := controller.MakeMove(move)
+	return err == nil
+}
+
+func checkPV(g *game.Game, result Result, t *testing.T) {
+	if len(result.PV) == 0 || result.PV[0] != result.Move {
+		t.Error("Expected the principal variation to start with", result.Move, "got", result.PV)
+		return
+	}
+	controller := game.NewController(game.StandardRules())
+	copyOfGame := *g
+	controller.PlayGame(&copyOfGame)
+	for _, move := range result.PV {
+		if _, err := controller.MakeMove(move); err != nil {
+			t.Error("Expected the principal variation", result.PV, "to be legal got", err, "for", move)
+			return
+		}
+	}
+}
+
+func TestSearchMatchesBestMove(t *testing.T) {
+	g := game.NewStandardGame()
+	searcher := NewSearcher(game.StandardRules(), nil, 3)
+	_, score, _ := searcher.BestMove(g)
+
+	result, err := searcher.Search(context.Background(), g, Limits{Depth: 3})
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if result.Score != score || result.Depth != 3 {
+		t.Error("Expected the score", score, "at depth 3 got", result.Score, "at depth", result.Depth)
+	}
+	if len(result.PV) != 3 {
+		t.Error("Expected a principal variation of 3 moves got", result.PV)
+	}
+	if result.Nodes == 0 {
+		t.Error("Expected the nodes to be counted")
+	}
+	checkPV(g, result, t)
+	if isEqual, difference := game.Compare(g, game.NewStandardGame()); !isEqual {
+		t.Error("Expected the game to be unchanged got", difference)
+	}
+}
+
+func TestSearchStopsAtAWin(t *testing.T) {
+	g := gameFromText("xxx2/2n2/o4/1x1x1/oooo1 1n", t)
+	searcher := NewSearcher(game.StandardRules(), nil, 0)
+
+	result, err := searcher.Search(context.Background(), g, Limits{})
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if result.Move != game.NewMove(2, 1, 0, 3) || result.Score != WinScore-2 || result.Depth != 2 {
+		t.Error("Expected to win with c2-a4 at depth 2 got", result)
+	}
+	if len(result.PV) != 2 {
+		t.Error("Expected the principal variation to be the winning turn got", result.PV)
+	}
+	checkPV(g, result, t)
+}
+
+func TestSearchNodeLimit(t *testing.T) {
+	g := game.NewStandardGame()
+	searcher := NewSearcher(game.StandardRules(), nil, 0)
+
+	for _, nodes := range []int{1, 10, 1000} {
+		result, err := searcher.Search(context.Background(), g, Limits{Nodes: nodes})
+		if err != nil {
+			t.Fatal("Expected no error got", err)
+		}
+		if result.Nodes > nodes {
+			t.Error("Expected at most", nodes, "nodes got", result.Nodes)
+		}
+		if !isLegalMove(g, result.Move) {
+			t.Error("Expected a legal move for", nodes, "nodes got", result.Move)
+		}
+		checkPV(g, result, t)
+	}
+}
+
+func TestSearchCancelledContext(t *testing.T) {
+	g := game.NewStandardGame()
+	searcher := NewSearcher(game.StandardRules(), nil, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := searcher.Search(ctx, g, Limits{})
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if result.Depth != 0 || result.Nodes != 0 {
+		t.Error("Expected no search got depth", result.Depth, "and", result.Nodes, "nodes")
+	}
+	if !isLegalMove(g, result.Move) {
+		t.Error("Expected a legal move got", result.Move)
+	}
+}
+
+func TestSearchTimeLimit(t *testing.T) {
+	g := game.NewStandardGame()
+	searcher := NewSearcher(game.StandardRules(), nil, 0)
+
+	start := time.Now()
+	result, err := searcher.Search(context.Background(), g, Limits{Time: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Error("Expected the search to stop after about 50ms, it took", elapsed)
+	}
+	if result.Depth == 0 || !isLegalMove(g, result.Move) {
+		t.Error("Expected a complete search with a legal move got", result)
+	}
+	checkPV(g, result, t)
+}
+
+func TestSearchErrors(t *testing.T) {
+	searcher := NewSearcher(game.StandardRules(), nil, 0)
+	_, err := searcher.Search(context.Background(), gameFromText("xxxxx/5/5/5/oonoo 1w", t), Limits{})
+	if err != ErrGameOver {
+		t.Error("Expected", ErrGameOver, "got", err)
+	}
+}
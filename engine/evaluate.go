@@ -0,0 +1,49 @@
+package engine
+
+import "github.com/Morras/go-neutrino/game"
+
+/**
+ * Scores a game that is not won from the point of view
+ * of the player, higher is better for the player. The
+ * score of a player must be the negated score of the
+ * opponent, and stay well below WinScore.
+ */
+type Evaluator func(g *game.Game, player game.Entry) int
+
+/**
+ * Prefers the neutrinos close to the home row of the
+ * opponent, as player 1 wins when a neutrino reaches the
+ * last row and player 2 when one reaches the first
+ */
+func NeutrinoDistance(g *game.Game, player game.Entry) int {
+	score := 0
+	for y := byte(0); y < g.Height(); y++ {
+		for x := byte(0); x < g.Width(); x++ {
+			if entry, _ := g.GetLocation(x, y); entry == game.Neutrino {
+				score += 2*int(y) - int(g.Height()-1)
+			}
+		}
+	}
+	if player == game.Player2 {
+		return -score
+	}
+	return score
+}
+
+//Leaves it to the search to find the wins
+func NoEvaluation(g *game.Game, player game.Entry) int {
+	return 0
+}
+
+/**
+ * The evaluator used when none is given. In the misère
+ * variant a neutrino close to a home row is as dangerous
+ * for the player as for the opponent, so only the search
+ * is used.
+ */
+func DefaultEvaluator(rules game.Rules) Evaluator {
+	if rules.Misere {
+		return NoEvaluation
+	}
+	return NeutrinoDistance
+}
@@ -0,0 +1,239 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/Morras/go-neutrino/game"
+)
+
+const (
+	defaultIterations = 10000
+
+	//A playout of more moves than this is scored as a draw,
+	//as the pieces can be moved back and forth forever
+	maxPlayoutMoves = 200
+)
+
+type Playout int
+
+const (
+	//Plays random legal moves until the game is won
+	RandomPlayout Playout = iota
+	//Plays a move winning right away when there is one,
+	//and a random legal move otherwise
+	HeuristicPlayout
+)
+
+/**
+ * Searches for the best move with Monte Carlo tree search,
+ * choosing moves to explore by UCT and scoring them by
+ * playing the game to the end. Every move is a node of the
+ * tree, so the neutrino move and the piece move of a turn
+ * are chosen one at a time. Games of four players are
+ * supported as a node counts the wins of the player
+ * making its move.
+ *
+ * A search with the same seed, rules and game always
+ * chooses the same move.
+ */
+type MCTS struct {
+	Rules game.Rules
+	//The number of playouts to make
+	Iterations int
+	//How much moves that have been visited few times are
+	//preferred over moves winning often, √2 in theory
+	Exploration float64
+	Seed        int64
+	Playout     Playout
+}
+
+/**
+ * A Monte Carlo tree search playing by the rules with
+ * random playouts. Iterations of 0 makes 10000 playouts
+ * and an exploration of 0 uses √2.
+ */
+func NewMCTS(rules game.Rules, iterations int, exploration float64, seed int64) *MCTS {
+	if iterations <= 0 {
+		iterations = defaultIterations
+	}
+	if exploration <= 0 {
+		exploration = math.Sqrt2
+	}
+	return &MCTS{Rules: rules, Iterations: iterations, Exploration: exploration, Seed: seed}
+}
+
+type MoveStatistics struct {
+	Move game.Move
+	//The number of playouts starting with the move
+	Visits int
+	//The share of the playouts won by the player to move,
+	//counting a draw as half a win
+	WinProbability float64
+}
+
+type MCTSResult struct {
+	//The move visited the most
+	Move game.Move
+	//The estimated probability that the player to move
+	//wins by playing Move
+	WinProbability float64
+	Iterations     int
+	//The statistics of every legal move, the most visited first
+	Moves []MoveStatistics
+}
+
+//A node of the tree, reached by making its move
+type mctsNode struct {
+	move game.Move
+	//The player making the move, whose wins are counted
+	player   game.Entry
+	parent   *mctsNode
+	children []*mctsNode
+	//The legal moves without a child yet
+	untried []game.Move
+	visits  int
+	wins    float64
+}
+
+/**
+ * The best move for the player to move in the game by
+ * the playouts. The game is not changed.
+ */
+func (self *MCTS) Search(g *game.Game) (MCTSResult, error) {
+	_, controller, err := playCopy(self.Rules, g)
+	if err != nil {
+		return MCTSResult{}, err
+	}
+	iterations := self.Iterations
+	if iterations <= 0 {
+		iterations = defaultIterations
+	}
+	exploration := self.Exploration
+	if exploration <= 0 {
+		exploration = math.Sqrt2
+	}
+	random := rand.New(rand.NewSource(self.Seed))
+
+	root := &mctsNode{untried: controller.LegalMoves()}
+	for i := 0; i < iterations; i++ {
+		node := root
+		//Selection
+		for len(node.untried) == 0 && len(node.children) > 0 {
+			node = node.bestChild(exploration)
+			controller.MakeMove(node.move)
+		}
+		//Expansion
+		if len(node.untried) > 0 {
+			node = node.expand(controller, random)
+		}
+		//Simulation
+		winner := self.playout(controller, random)
+		//Backpropagation
+		for ; node != nil; node = node.parent {
+			node.visits++
+			if winner == game.EmptySquare {
+				node.wins += 0.5
+			} else if node.player == winner {
+				node.wins++
+			}
+		}
+		for controller.Undo() == nil {
+		}
+	}
+
+	return root.result(iterations), nil
+}
+
+//The child with the highest upper confidence bound
+func (self *mctsNode) bestChild(exploration float64) *mctsNode {
+	var best *mctsNode
+	bestBound := math.Inf(-1)
+	logVisits := math.Log(float64(self.visits))
+	for _, child := range self.children {
+		visits := float64(child.visits)
+		bound := child.wins/visits + exploration*math.Sqrt(logVisits/visits)
+		if bound > bestBound {
+			best, bestBound = child, bound
+		}
+	}
+	return best
+}
+
+//Makes a random untried move and adds its node to the tree
+func (self *mctsNode) expand(controller *game.Controller, random *rand.Rand) *mctsNode {
+	i := random.Intn(len(self.untried))
+	move := self.untried[i]
+	last := len(self.untried) - 1
+	self.untried[i] = self.untried[last]
+	self.untried = self.untried[:last]
+
+	player := controller.Game().State.Player()
+	controller.MakeMove(move)
+	child := &mctsNode{move: move, player: player, parent: self}
+	if !controller.Game().State.IsWin() {
+		child.untried = controller.LegalMoves()
+	}
+	self.children = append(self.children, child)
+	return child
+}
+
+/**
+ * Plays the game to the end and returns the winner,
+ * EmptySquare for a draw. The moves are made on the
+ * controller so they can be undone.
+ */
+func (self *MCTS) playout(controller *game.Controller, random *rand.Rand) game.Entry {
+	g := controller.Game()
+	for i := 0; i < maxPlayoutMoves && !g.State.IsWin(); i++ {
+		moves := controller.LegalMoves()
+		if len(moves) == 0 {
+			return game.EmptySquare
+		}
+		if self.Playout == HeuristicPlayout {
+			if move, ok := winningMove(controller, moves); ok {
+				controller.MakeMove(move)
+				continue
+			}
+		}
+		controller.MakeMove(moves[random.Intn(len(moves))])
+	}
+	if !g.State.IsWin() {
+		return game.EmptySquare
+	}
+	return g.State.Player()
+}
+
+//A move winning the game right away for the player to move
+func winningMove(controller *game.Controller, moves []game.Move) (game.Move, bool) {
+	player := controller.Game().State.Player()
+	for _, move := range moves {
+		state, _ := controller.MakeMove(move)
+		controller.Undo()
+		if state.IsWin() && state.Player() == player {
+			return move, true
+		}
+	}
+	return game.Move{}, false
+}
+
+func (self *mctsNode) result(iterations int) MCTSResult {
+	result := MCTSResult{Iterations: iterations}
+	for _, child := range self.children {
+		result.Moves = append(result.Moves, MoveStatistics{
+			Move:           child.move,
+			Visits:         child.visits,
+			WinProbability: child.wins / float64(child.visits),
+		})
+	}
+	for _, move := range self.untried {
+		result.Moves = append(result.Moves, MoveStatistics{Move: move})
+	}
+	sort.SliceStable(result.Moves, func(i, j int) bool {
+		return result.Moves[i].Visits > result.Moves[j].Visits
+	})
+	result.Move = result.Moves[0].Move
+	result.WinProbability = result.Moves[0].WinProbability
+	return result
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Morras/go-neutrino/game"
+)
+
+func TestMCTSWinsRightAway(t *testing.T) {
+	for _, playout := range []Playout{RandomPlayout, HeuristicPlayout} {
+		g := gameFromText("xxxxx/5/2n2/5/oo1oo 1n", t)
+		mcts := NewMCTS(game.StandardRules(), 2000, 0, 1)
+		mcts.Playout = playout
+
+		result, err := mcts.Search(g)
+		if err != nil {
+			t.Fatal("Expected no error got", err)
+		}
+		if result.Move != game.NewMove(2, 2, 2, 4) || result.WinProbability != 1 {
+			t.Error("Expected to win with c3-c5 using playout", playout, "got", result.Move,
+				"with a win probability of", result.WinProbability)
+		}
+		if isEqual, difference := game.Compare(g, gameFromText("xxxxx/5/2n2/5/oo1oo 1n", t)); !isEqual {
+			t.Error("Expected the game to be unchanged got", difference)
+		}
+	}
+}
+
+func TestMCTSStatistics(t *testing.T) {
+	g := game.NewStandardGame()
+	controller := game.NewController(game.StandardRules())
+	controller.PlayGame(g)
+	legalMoves := len(controller.LegalMoves())
+
+	result, err := NewMCTS(game.StandardRules(), 500, 0, 1).Search(g)
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if result.Iterations != 500 || len(result.Moves) != legalMoves {
+		t.Error("Expected 500 iterations over", legalMoves, "moves got", result.Iterations, "over", len(result.Moves))
+	}
+	visits := 0
+	for i, move := range result.Moves {
+		visits += move.Visits
+		if move.WinProbability < 0 || move.WinProbability > 1 {
+			t.Error("Expected a probability got", move.WinProbability, "for", move.Move)
+		}
+		if i > 0 && move.Visits > result.Moves[i-1].Visits {
+			t.Error("Expected the moves to be sorted by visits got", result.Moves)
+		}
+	}
+	if visits != 500 {
+		t.Error("Expected every iteration to visit a move got", visits, "visits")
+	}
+	if result.Move != result.Moves[0].Move || result.WinProbability != result.Moves[0].WinProbability {
+		t.Error("Expected the most visited move", result.Moves[0], "got", result.Move)
+	}
+}
+
+func TestMCTSIsReproducible(t *testing.T) {
+	g := gameFromText("1x1x1/x3x/1o1n1/o3o/2o2 2n", t)
+	mcts := NewMCTS(game.StandardRules(), 300, 0.7, 42)
+	mcts.Playout = HeuristicPlayout
+
+	first, _ := mcts.Search(g)
+	second, _ := mcts.Search(g)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same result from the same seed got", first, "and", second)
+	}
+}
+
+func TestMCTSFourPlayers(t *testing.T) {
+	g := game.NewFourPlayerGame()
+	result, err := NewMCTS(game.FourPlayerRules(), 200, 0, 1).Search(g)
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+
+	controller := game.NewController(game.FourPlayerRules())
+	controller.PlayGame(g)
+	if _, err := controller.MakeMove(result.Move); err != nil {
+		t.Error("Expected a legal move got", result.Move, err)
+	}
+}
+
+func TestMCTSErrors(t *testing.T) {
+	_, err := NewMCTS(game.StandardRules(), 10, 0, 1).Search(gameFromText("xxxxx/5/5/5/oonoo 1w", t))
+	if err != ErrGameOver {
+		t.Error("Expected", ErrGameOver, "got", err)
+	}
+}
+
+func BenchmarkMCTS(b *testing.B) {
+	g := game.NewStandardGame()
+	mcts := NewMCTS(game.StandardRules(), 1000, 0, 1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		mcts.Search(g)
+	}
+}
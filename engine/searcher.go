@@ -0,0 +1,268 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/Morras/go-neutrino/game"
+)
+
+var (
+	ErrGameOver           = errors.New("Cannot search a game that has been won")
+	ErrNoLegalMoves       = errors.New("There are no legal moves to search")
+	ErrUnsupportedPlayers = errors.New("The searcher only supports games of two players")
+)
+
+const (
+	//The score of winning right away, a win after more
+	//moves scores one less for every move
+	WinScore = 1000000
+
+	defaultDepth = 4
+)
+
+/**
+ * Searches for the best move with negamax and alpha-beta
+ * pruning. A player moves twice in a row, first the neutrino
+ * and then a piece, so the score is only negated when the
+ * player to move changes.
+ */
+type Searcher struct {
+	Rules    game.Rules
+	Evaluate Evaluator
+	//The number of moves to search ahead, counting the
+	//neutrino move and the piece move as a move each
+	Depth int
+}
+
+/**
+ * A searcher playing by the rules, a nil evaluate
+ * uses DefaultEvaluator and a depth of 0 searches
+ * 4 moves ahead
+ */
+func NewSearcher(rules game.Rules, evaluate Evaluator, depth int) *Searcher {
+	if evaluate == nil {
+		evaluate = DefaultEvaluator(rules)
+	}
+	if depth <= 0 {
+		depth = defaultDepth
+	}
+	return &Searcher{Rules: rules, Evaluate: evaluate, Depth: depth}
+}
+
+/**
+ * The best move for the player to move in the game along
+ * with its score from the point of view of that player.
+ * The game is not changed.
+ */
+func (self *Searcher) BestMove(g *game.Game) (game.Move, int, error) {
+	search, err := self.newSearch(g)
+	if err != nil {
+		return game.Move{}, 0, err
+	}
+	move, score, _ := search.root(self.Depth, game.Move{})
+	return move, score, nil
+}
+
+//The state of a single search on a copy of the game
+type search struct {
+	evaluate   Evaluator
+	game       *game.Game
+	controller *game.Controller
+	//The principal variation found from each ply,
+	//pv[0] is the principal variation of the root
+	pv [][]game.Move
+	//The number of moves made by the search, when it
+	//should stop and whether it has stopped
+	nodes   int
+	stop    stopCondition
+	stopped bool
+}
+
+func (self *Searcher) newSearch(g *game.Game) (*search, error) {
+	if self.Rules.Players > 2 {
+		return nil, ErrUnsupportedPlayers
+	}
+	copyOfGame, controller, err := playCopy(self.Rules, g)
+	if err != nil {
+		return nil, err
+	}
+	evaluate := self.Evaluate
+	if evaluate == nil {
+		evaluate = DefaultEvaluator(self.Rules)
+	}
+	return &search{
+		evaluate:   evaluate,
+		game:       copyOfGame,
+		controller: controller,
+	}, nil
+}
+
+/**
+ * Plays a copy of the game by the rules, so searching
+ * does not change the game. Fails if there is no move
+ * to search.
+ */
+func playCopy(rules game.Rules, g *game.Game) (*game.Game, *game.Controller, error) {
+	copyOfGame := *g
+	controller := game.NewController(rules)
+	controller.PlayGame(&copyOfGame)
+	if copyOfGame.State.IsWin() {
+		return nil, nil, ErrGameOver
+	}
+	if len(controller.LegalMoves()) == 0 {
+		return nil, nil, ErrNoLegalMoves
+	}
+	return &copyOfGame, controller, nil
+}
+
+/**
+ * Searches depth moves ahead, trying first before the other
+ * moves, e.g. the best move of a shallower search. The best
+ * move is the zero move if the search was stopped before any
+ * move was scored, and the score is only reliable when the
+ * search was complete.
+ */
+func (self *search) root(depth int, first game.Move) (bestMove game.Move, best int, complete bool) {
+	best = -WinScore - 1
+	player := self.game.State.Player()
+	alpha, beta := -WinScore-1, WinScore+1
+	self.clearPV(0)
+	for _, move := range self.rootMoves(player, first) {
+		score := self.scoreMove(move, player, depth, 1, alpha, beta)
+		if self.stopped {
+			return bestMove, best, false
+		}
+		if score > best {
+			best, bestMove = score, move
+		}
+		if score > alpha {
+			alpha = score
+			self.updatePV(0, move)
+		}
+	}
+	return bestMove, best, true
+}
+
+//The ordered moves of the root with first moved to the front
+func (self *search) rootMoves(player game.Entry, first game.Move) []game.Move {
+	moves := self.orderedMoves(player)
+	for i, move := range moves {
+		if move == first {
+			copy(moves[1:i+1], moves[:i])
+			moves[0] = first
+			break
+		}
+	}
+	return moves
+}
+
+/**
+ * Scores the game from the point of view of the player
+ * to move, looking depth moves ahead. ply is the number
+ * of moves made since the root of the search.
+ */
+func (self *search) negamax(depth, ply, alpha, beta int) int {
+	player := self.game.State.Player()
+	self.clearPV(ply)
+	if depth == 0 {
+		return self.evaluate(self.game, player)
+	}
+	moves := self.orderedMoves(player)
+	if len(moves) == 0 {
+		return self.evaluate(self.game, player)
+	}
+
+	best := -WinScore - 1
+	for _, move := range moves {
+		score := self.scoreMove(move, player, depth, ply+1, alpha, beta)
+		if self.stopped {
+			return 0
+		}
+		if score > best {
+			best = score
+		}
+		if score > alpha {
+			alpha = score
+			self.updatePV(ply, move)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
+
+/**
+ * Makes the move and scores it for the player making
+ * it. If the same player moves again the score and the
+ * window are used as they are, otherwise they are negated.
+ */
+func (self *search) scoreMove(move game.Move, player game.Entry, depth, ply, alpha, beta int) int {
+	self.controller.MakeMove(move)
+	defer self.controller.Undo()
+	self.clearPV(ply)
+	if self.countNode() {
+		return 0
+	}
+
+	state := self.game.State
+	switch {
+	case state.IsWin() && state.Player() == player:
+		return WinScore - ply
+	case state.IsWin():
+		return -WinScore + ply
+	case state.Player() == player:
+		return self.negamax(depth-1, ply, alpha, beta)
+	default:
+		return -self.negamax(depth-1, ply, -beta, -alpha)
+	}
+}
+
+//Forgets the principal variation from the ply
+func (self *search) clearPV(ply int) {
+	for len(self.pv) <= ply {
+		self.pv = append(self.pv, nil)
+	}
+	self.pv[ply] = self.pv[ply][:0]
+}
+
+//The move followed by the principal variation of the next ply
+func (self *search) updatePV(ply int, move game.Move) {
+	self.pv[ply] = append(append(self.pv[ply][:0], move), self.pv[ply+1]...)
+}
+
+/**
+ * The legal moves with the most promising first, by
+ * the evaluation of the game after each move. Moves
+ * winning the game come first and losing moves last.
+ */
+func (self *search) orderedMoves(player game.Entry) []game.Move {
+	type scoredMove struct {
+		move  game.Move
+		score int
+	}
+	moves := self.controller.LegalMoves()
+	scored := make([]scoredMove, len(moves))
+	for i, move := range moves {
+		self.controller.MakeMove(move)
+		state := self.game.State
+		scored[i].move = move
+		switch {
+		case state.IsWin() && state.Player() == player:
+			scored[i].score = WinScore
+		case state.IsWin():
+			scored[i].score = -WinScore
+		default:
+			scored[i].score = self.evaluate(self.game, player)
+		}
+		self.controller.Undo()
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	for i := range scored {
+		moves[i] = scored[i].move
+	}
+	return moves
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/Morras/go-neutrino/game"
+)
+
+func gameFromText(text string, t *testing.T) *game.Game {
+	g := &game.Game{}
+	if err := g.UnmarshalText([]byte(text)); err != nil {
+		t.Fatal("Could not set up", text, err)
+	}
+	return g
+}
+
+func TestBestMoveWinsRightAway(t *testing.T) {
+	g := gameFromText("xxxxx/5/2n2/5/oo1oo 1n", t)
+	searcher := NewSearcher(game.StandardRules(), nil, 1)
+
+	move, score, err := searcher.BestMove(g)
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if move != game.NewMove(2, 2, 2, 4) || score != WinScore-1 {
+		t.Error("Expected to win with c3-c5 got", move, "with score", score)
+	}
+	if isEqual, difference := game.Compare(g, gameFromText("xxxxx/5/2n2/5/oo1oo 1n", t)); !isEqual {
+		t.Error("Expected the game to be unchanged got", difference)
+	}
+}
+
+func TestBestMoveBlocksTheOpponent(t *testing.T) {
+	//Player 2 moves the neutrino to the first row
+	//unless player 1 blocks the third column
+	g := gameFromText("xx1xx/5/2n2/5/ooooo 1p", t)
+	searcher := NewSearcher(game.StandardRules(), nil, 2)
+
+	move, score, err := searcher.BestMove(g)
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if score <= -WinScore/2 {
+		t.Error("Expected to find a move that does not lose got", move, "with score", score)
+	}
+
+	controller := game.NewController(game.StandardRules())
+	controller.PlayGame(g)
+	controller.MakeMove(move)
+	for _, neutrinoMove := range controller.LegalMoves() {
+		if state, _ := controller.MakeMove(neutrinoMove); state == game.Player2Win {
+			t.Error("Expected", move, "to stop player 2 from winning by", neutrinoMove)
+		}
+		controller.Undo()
+	}
+}
+
+func TestBestMoveUsesBothMovesOfTheTurn(t *testing.T) {
+	//No neutrino move wins right away, but moving it
+	//to a4 lets player 1 trap it with the piece move
+	g := gameFromText("xxx2/2n2/o4/1x1x1/oooo1 1n", t)
+	searcher := NewSearcher(game.StandardRules(), nil, 2)
+
+	move, score, err := searcher.BestMove(g)
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if move != game.NewMove(2, 1, 0, 3) || score != WinScore-2 {
+		t.Error("Expected to win after two moves with c2-a4 got", move, "with score", score)
+	}
+}
+
+/**
+ * Plain minimax scoring from the point of view of
+ * player 1 without any pruning, to check the scores
+ * of negamax
+ */
+func minimax(controller *game.Controller, depth, ply int) int {
+	g := controller.Game()
+	switch {
+	case g.State == game.Player1Win:
+		return WinScore - ply
+	case g.State == game.Player2Win:
+		return -WinScore + ply
+	case depth == 0:
+		return NeutrinoDistance(g, game.Player1)
+	}
+
+	maximize := g.State.Player() == game.Player1
+	best := WinScore + 1
+	if maximize {
+		best = -WinScore - 1
+	}
+	for _, move := range controller.LegalMoves() {
+		controller.MakeMove(move)
+		score := minimax(controller, depth-1, ply+1)
+		controller.Undo()
+		if (maximize && score > best) || (!maximize && score < best) {
+			best = score
+		}
+	}
+	return best
+}
+
+func TestBestMoveScoreMatchesMinimax(t *testing.T) {
+	positions := []string{
+		"xxxxx/5/2n2/5/ooooo 1n",
+		"x1xxx/3x1/4o/n4/oo1oo 2n",
+		"xx1xx/5/2n2/5/ooooo 1p",
+		"1x1x1/x3x/1o1n1/o3o/2o2 1n",
+		"1x1x1/x3x/1o1n1/o3o/2o2 2p"}
+
+	for _, text := range positions {
+		for depth := 1; depth <= 3; depth++ {
+			g := gameFromText(text, t)
+			_, score, err := NewSearcher(game.StandardRules(), NeutrinoDistance, depth).BestMove(g)
+			if err != nil {
+				t.Fatal("Expected no error for", text, "got", err)
+			}
+			if g.State.Player() == game.Player2 {
+				score = -score
+			}
+
+			controller := game.NewController(game.StandardRules())
+			controller.PlayGame(g)
+			if expected := minimax(controller, depth, 0); score != expected {
+				t.Error("Expected the score", expected, "for", text, "at depth", depth, "got", score)
+			}
+		}
+	}
+}
+
+func TestBestMoveErrors(t *testing.T) {
+	searcher := NewSearcher(game.StandardRules(), nil, 2)
+	if _, _, err := searcher.BestMove(gameFromText("xxxxx/5/5/5/oonoo 1w", t)); err != ErrGameOver {
+		t.Error("Expected", ErrGameOver, "got", err)
+	}
+
+	searcher = NewSearcher(game.FourPlayerRules(), nil, 2)
+	if _, _, err := searcher.BestMove(game.NewFourPlayerGame()); err != ErrUnsupportedPlayers {
+		t.Error("Expected", ErrUnsupportedPlayers, "got", err)
+	}
+}
+
+func TestBestMoveMisere(t *testing.T) {
+	//Moving the neutrino to the fifth row loses in the misère
+	//variant, so the neutrino should be kept away from it
+	g := gameFromText("xxxxx/5/2n2/5/oo1oo 1n", t)
+	searcher := NewSearcher(game.MisereRules(), nil, 1)
+
+	move, score, err := searcher.BestMove(g)
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if move == game.NewMove(2, 2, 2, 4) || score < 0 {
+		t.Error("Expected to not lose by moving to the fifth row got", move, "with score", score)
+	}
+}
+
+func BenchmarkBestMove(b *testing.B) {
+	g := game.NewStandardGame()
+	searcher := NewSearcher(game.StandardRules(), nil, 4)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		searcher.BestMove(g)
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import "sync"
+
+/**
+ * Besides the entry of every square a game keeps a
+ * bitboard for each known entry, a 64 bit mask with the
+ * bit x + width*y set for every square holding the entry,
+ * and a mask of the squares that are not empty. Moves are
+ * found by walking rays of squares that are precomputed
+ * for each board geometry, so no coordinates have to be
+ * checked while moving a piece.
+ */
+
+const entryKinds = Player4 + 1
+
+//The index of every direction in directions
+var directionIndexes = func() (indexes [SE + 1]byte) {
+	for i, direction := range directions {
+		indexes[direction] = byte(i)
+	}
+	return indexes
+}()
+
+//The size of the board and the edges that are wrapped
+type geometry struct {
+	width, height         byte
+	wrapColumns, wrapRows bool
+}
+
+const (
+	pieceRays = iota
+	neutrinoRays
+)
+
+/**
+ * The squares a piece passes when sliding from a square
+ * in each direction, in the order they are passed. A ray
+ * ends at the edge of the board or, when the edges wrap,
+ * at the square it started from. The neutrino has rays of
+ * its own as it never wraps around the top and bottom.
+ */
+type rayTable struct {
+	geometry geometry
+	rays     [2][len(directions)][maxSquares][]byte
+	//The squares next to a square that a piece can move to
+	neighbours [2][maxSquares]uint64
+	homeEdges  [entryKinds]uint64
+}
+
+var rayTables sync.Map
+
+//The ray table of the geometry, computed the first time it is used
+func rayTableFor(geometry geometry) *rayTable {
+	if table, ok := rayTables.Load(geometry); ok {
+		return table.(*rayTable)
+	}
+	table, _ := rayTables.LoadOrStore(geometry, newRayTable(geometry))
+	return table.(*rayTable)
+}
+
+func newRayTable(geometry geometry) *rayTable {
+	table := &rayTable{geometry: geometry}
+	width, height := geometry.width, geometry.height
+	for y := byte(0); y < height; y++ {
+		for x := byte(0); x < width; x++ {
+			square := x + width*y
+			for i, direction := range directions {
+				table.rays[pieceRays][i][square] = newRay(geometry, x, y, direction, geometry.wrapRows)
+				table.rays[neutrinoRays][i][square] = newRay(geometry, x, y, direction, false)
+				for kind := range table.rays {
+					if ray := table.rays[kind][i][square]; len(ray) > 0 {
+						table.neighbours[kind][square] |= 1 << ray[0]
+					}
+				}
+			}
+
+			bit := uint64(1) << square
+			if y == 0 {
+				table.homeEdges[Player1] |= bit
+			}
+			if y == height-1 {
+				table.homeEdges[Player2] |= bit
+			}
+			if x == width-1 {
+				table.homeEdges[Player3] |= bit
+			}
+			if x == 0 {
+				table.homeEdges[Player4] |= bit
+			}
+		}
+	}
+	return table
+}
+
+func newRay(geometry geometry, startX, startY byte, direction Direction, wrapRows bool) []byte {
+	deltaX, deltaY := getDirectionDelta(direction)
+	var ray []byte
+	x, y := int(startX), int(startY)
+	for {
+		x += deltaX
+		y += deltaY
+		if geometry.wrapColumns {
+			x = wrapCoordinate(x, geometry.width)
+		}
+		if wrapRows {
+			y = wrapCoordinate(y, geometry.height)
+		}
+		if x < 0 || y < 0 || x >= int(geometry.width) || y >= int(geometry.height) {
+			return ray
+		}
+		ray = append(ray, byte(x)+geometry.width*byte(y))
+		if x == int(startX) && y == int(startY) {
+			return ray
+		}
+	}
+}
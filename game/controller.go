@@ -2,34 +2,89 @@ package game
 
 import "fmt"
 import "math"
+import "math/bits"
 
 type Controller struct {
-	game *Game
+	game    *Game
+	rules   Rules
+	history []playedMove
+	ply     int
+	//The rays of the board the game is played on
+	rays *rayTable
+	//Whether the move being made is the extra neutrino
+	//move of the handicap, as the board no longer shows
+	//it once the neutrino has moved
+	extraNeutrinoMove bool
 }
 
 type GameController interface {
 	PlayGame(game *Game)
 	Game() *Game
+	Rules() Rules
 	MakeMove(m Move) (State, error)
+	MakeTurn(neutrinoMove, pieceMove Move) (State, error)
+	LegalMoves() []Move
+	LegalTurns() []Turn
+	Undo() error
+	Redo() error
+	History() []Move
+	Ply() int
 }
 
+/**
+ * A move made through the controller along with
+ * the states before and after it, so it can be
+ * undone and redone
+ */
+type playedMove struct {
+	move        Move
+	stateBefore State
+	stateAfter  State
+	reasonAfter WinReason
+}
+
+/**
+ * A controller playing by the given rules, a
+ * zero Controller plays by the standard rules
+ */
+func NewController(rules Rules) *Controller {
+	return &Controller{rules: rules}
+}
+
+/**
+ * Starts playing the game from its current position.
+ * If the rules skip the first neutrino move a game in
+ * the starting position of any variant is moved on to
+ * Player1Move, which changes the state of the game.
+ * Play a copy to keep the game in Player1NeutrinoMove.
+ */
 func (self *Controller) PlayGame(g *Game) {
 	self.game = g
+	self.history = nil
+	self.ply = 0
+	if self.rules.SkipFirstNeutrinoMove && g.isOpening() {
+		g.State = Player1Move
+	}
 }
 
 func (self *Controller) Game() *Game {
 	return self.game
 }
 
+func (self *Controller) Rules() Rules {
+	return self.rules
+}
+
 func (self *Controller) MakeMove(m Move) (State, error) {
 
-	if legalMove, errorMsg := self.isMoveLegal(m); !legalMove {
-		return self.game.State, fmt.Errorf(errorMsg)
+	if err := self.isMoveLegal(m); err != nil {
+		return self.game.State, err
 	}
 
+	self.extraNeutrinoMove = self.isExtraNeutrinoMove()
 	self.move(m)
 
-	winnerExists, winnerState, err := self.isThereAWinner()
+	winnerExists, winnerState, winReason, err := self.isThereAWinner()
 	if err != nil {
 		//Reset the move so that both the board and the state
 		//reflects that nothing has happened due to an error.
@@ -42,32 +97,234 @@ func (self *Controller) MakeMove(m Move) (State, error) {
 		return self.game.State, err
 	}
 
+	stateBefore := self.game.State
 	if winnerExists {
 		self.game.State = winnerState
-		return winnerState, nil
+		self.game.WinReason = winReason
+	} else {
+		self.game.State = self.getNextState()
 	}
-
-	self.game.State = self.getNextState()
+	self.record(m, stateBefore)
 	return self.game.State, nil
 }
 
-func (self *Controller) isMoveLegal(move Move) (answer bool, message string) {
+/**
+ * Makes a full turn, the neutrino move followed by the
+ * piece move. If the piece move is illegal the neutrino
+ * move is taken back, so either both moves are made or
+ * none of them. The piece move is ignored when the turn
+ * ends after the neutrino move, e.g. when the game is won.
+ *
+ * A turn starting with a piece move, e.g. the first
+ * turn by the official rules, has a zero neutrino move.
+ */
+func (self *Controller) MakeTurn(neutrinoMove, pieceMove Move) (State, error) {
+	player := self.game.State.Player()
+	if neutrinoMove == (Move{}) {
+		if self.game.State.IsNeutrinoMove() {
+			return self.game.State, newMoveError(ReasonWrongTurn, pieceMove, pieceMove.FromX, pieceMove.FromY)
+		}
+		return self.MakeMove(pieceMove)
+	}
+	if !self.game.State.IsNeutrinoMove() {
+		return self.game.State, newMoveError(ReasonWrongTurn, neutrinoMove, neutrinoMove.FromX, neutrinoMove.FromY)
+	}
 
-	if answer, msg := self.isMoveValidForState(move); !answer {
-		return false, msg
+	state, err := self.MakeMove(neutrinoMove)
+	if err != nil || state != statesOfPlayer[player].pieceMove {
+		return state, err
 	}
 
-	if (move.ToY == 0 && move.FromY != 0 && self.game.State == Player1Move && self.getOwnPiecesOnHomeRow(1) == 4) ||
-		(move.ToY == 4 && move.FromY != 4 && self.game.State == Player2Move && self.getOwnPiecesOnHomeRow(2) == 4) {
-		return false, "Cannot move all five pieces back on home row"
+	state, err = self.MakeMove(pieceMove)
+	if err != nil {
+		self.Undo()
+		//The neutrino move should not be redone
+		self.history = self.history[:self.ply]
+		return self.game.State, err
 	}
+	return state, nil
+}
 
+/**
+ * Takes back the last move, restoring both the
+ * board and the state of the game
+ */
+func (self *Controller) Undo() error {
+	if self.ply == 0 {
+		return ErrNothingToUndo
+	}
+	self.ply--
+	played := self.history[self.ply]
+	self.move(NewMove(played.move.ToX, played.move.ToY, played.move.FromX, played.move.FromY))
+	self.game.State = played.stateBefore
+	//A move can only be made when the game is not won
+	self.game.WinReason = NoWinner
+	return nil
+}
+
+/**
+ * Replays the last move that was undone. Making a new
+ * move after an undo discards the moves that could
+ * have been redone
+ */
+func (self *Controller) Redo() error {
+	if self.ply == len(self.history) {
+		return ErrNothingToRedo
+	}
+	played := self.history[self.ply]
+	self.move(played.move)
+	self.game.State = played.stateAfter
+	self.game.WinReason = played.reasonAfter
+	self.ply++
+	return nil
+}
+
+/**
+ * The moves played through the controller
+ * up until the current ply
+ */
+func (self *Controller) History() []Move {
+	moves := make([]Move, self.ply)
+	for i := range moves {
+		moves[i] = self.history[i].move
+	}
+	return moves
+}
+
+/**
+ * The number of moves played through the controller
+ * not counting moves that has been undone
+ */
+func (self *Controller) Ply() int {
+	return self.ply
+}
+
+func (self *Controller) record(m Move, stateBefore State) {
+	self.history = append(self.history[:self.ply], playedMove{
+		move:        m,
+		stateBefore: stateBefore,
+		stateAfter:  self.game.State,
+		reasonAfter: self.game.WinReason,
+	})
+	self.ply++
+}
+
+/**
+ * Returns every move that MakeMove would accept
+ * in the current state of the game. A piece always
+ * slides until it hits an obstacle so there is at
+ * most one move per piece and direction.
+ */
+func (self *Controller) LegalMoves() []Move {
+	return self.legalMoves(false)
+}
+
+/**
+ * Returns every turn MakeTurn would accept in the
+ * current state of the game. A turn that ends after
+ * the neutrino move has a zero PieceMove, and when it
+ * is time to move a piece the turns have a zero
+ * NeutrinoMove.
+ */
+func (self *Controller) LegalTurns() []Turn {
+	if !self.game.State.IsNeutrinoMove() {
+		var turns []Turn
+		for _, pieceMove := range self.LegalMoves() {
+			turns = append(turns, Turn{PieceMove: pieceMove})
+		}
+		return turns
+	}
+	player := self.game.State.Player()
+	//Limiting the capacity makes the moves tried below
+	//append to a copy, so the moves that can be redone
+	//are kept
+	history := self.history
+	self.history = history[:self.ply:self.ply]
+
+	var turns []Turn
+	for _, neutrinoMove := range self.LegalMoves() {
+		if state, _ := self.MakeMove(neutrinoMove); state != statesOfPlayer[player].pieceMove {
+			turns = append(turns, Turn{NeutrinoMove: neutrinoMove})
+		} else {
+			for _, pieceMove := range self.LegalMoves() {
+				turns = append(turns, NewTurn(neutrinoMove, pieceMove))
+			}
+		}
+		self.Undo()
+	}
+	self.history = history
+	return turns
+}
+
+func (self *Controller) legalMoves(firstOnly bool) []Move {
+	state := self.game.State
+	if state.IsWin() || !self.rules.isPlaying(state.Player()) {
+		return nil
+	}
+	movingPieces := self.game.pieces[Neutrino]
+	if !state.IsNeutrinoMove() {
+		movingPieces = self.game.pieces[state.Player()]
+	}
+
+	width := self.game.Width()
+	var moves []Move
+	for movingPieces != 0 {
+		square := byte(bits.TrailingZeros64(movingPieces))
+		movingPieces &= movingPieces - 1
+		x, y := square%width, square/width
+		for _, direction := range directions {
+			toX, toY, steps := self.slide(x, y, direction)
+			if steps == 0 {
+				continue
+			}
+			move := NewMove(x, y, toX, toY)
+			if !self.isHomeRowRestricted(move) {
+				moves = append(moves, move)
+				if firstOnly {
+					return moves
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (self *Controller) isMoveLegal(move Move) error {
+
+	if err := self.isMoveValidForState(move); err != nil {
+		return err
+	}
+
+	if _, err := self.game.GetLocation(move.ToX, move.ToY); err != nil {
+		return newMoveError(ReasonOutOfBounds, move, move.ToX, move.ToY)
+	}
+
+	if self.isHomeRowRestricted(move) {
+		return newMoveError(ReasonHomeRowRestriction, move, move.ToX, move.ToY)
+	}
+
+	err := self.isMoveInStraightLineLegal(move)
+	if err != nil && (self.rules.WrapColumns || self.rules.WrapRows) && self.isMoveAroundTheBoardLegal(move) {
+		return nil
+	}
+	return err
+}
+
+//Whether the move would put every piece of the player on their home row
+func (self *Controller) isHomeRowRestricted(move Move) bool {
+	player := self.game.State.Player()
+	return !self.rules.AllowFullHomeRow && !self.game.State.IsNeutrinoMove() &&
+		self.isOnHomeEdge(player, move.ToX, move.ToY) && !self.isOnHomeEdge(player, move.FromX, move.FromY) &&
+		self.getOwnPiecesOnHomeEdge(player) == self.piecesPerPlayer(player)-1
+}
+
+func (self *Controller) isMoveInStraightLineLegal(move Move) error {
 	//Need to change from byte to int8 to prevent underflow
 	deltaX := int8(move.ToX - move.FromX)
 	deltaY := int8(move.ToY - move.FromY)
 
 	if deltaX != 0 && deltaY != 0 && (deltaX != deltaY && deltaX != -deltaY) {
-		return false, "Piece must be move in a straight line"
+		return newMoveError(ReasonNotStraightLine, move, move.ToX, move.ToY)
 	}
 
 	direction := Origo
@@ -84,85 +341,162 @@ func (self *Controller) isMoveLegal(move Move) (answer bool, message string) {
 
 	steps := math.Max(math.Abs(float64(deltaX)), math.Abs(float64(deltaY)))
 
-	return self.isMoveByDirectionLegal(move.FromX, move.FromY, direction, byte(steps))
+	return self.isMoveByDirectionLegal(move, direction, byte(steps))
 }
 
-func (self *Controller) isMoveValidForState(move Move) (answer bool, message string) {
+/**
+ * On a board that wraps around the edges a piece can
+ * reach its destination by leaving the board, so every
+ * direction is tried
+ */
+func (self *Controller) isMoveAroundTheBoardLegal(move Move) bool {
+	for _, direction := range directions {
+		toX, toY, steps := self.slide(move.FromX, move.FromY, direction)
+		if steps > 0 && toX == move.ToX && toY == move.ToY {
+			return true
+		}
+	}
+	return false
+}
+
+//Where a piece ends up when sliding in a direction until it hits an obstacle
+func (self *Controller) slide(x, y byte, direction Direction) (toX, toY, steps byte) {
+	ray := self.getRay(x, y, direction)
+	for int(steps) < len(ray) && self.game.occupied&(1<<ray[steps]) == 0 {
+		steps++
+	}
+	if steps == 0 {
+		return x, y, 0
+	}
+	width := self.game.Width()
+	return ray[steps-1] % width, ray[steps-1] / width, steps
+}
+
+func (self *Controller) isMoveValidForState(move Move) error {
 
 	state := self.game.State
-	if state == Player1Win || state == Player2Win {
-		return false, "Cannot move as the game has been won"
+	if state.IsWin() {
+		return newMoveError(ReasonGameOver, move, move.FromX, move.FromY)
+	}
+	if !self.rules.isPlaying(state.Player()) {
+		//A corrupt state can name a player that is not playing
+		return newMoveError(ReasonWrongTurn, move, move.FromX, move.FromY)
 	}
 
 	entry, err := self.game.GetLocation(move.FromX, move.FromY)
 	if err != nil {
-		return false, err.Error()
+		return newMoveError(ReasonOutOfBounds, move, move.FromX, move.FromY)
 	}
 
 	if entry == EmptySquare {
-		return false, "Move must start at a non empty board location"
-	} else if entry == Player1 && state != Player1Move {
-		return false, "It must be player 1 turn to move player 1 piece"
-	} else if entry == Player2 && state != Player2Move {
-		return false, "It must be player 2 turn to move player 2 piece"
-	} else if entry == Neutrino && state != Player1NeutrinoMove && state != Player2NeutrinoMove {
-		return false, "It must be either player 1 or player 2 turn to move neutrino"
+		return newMoveError(ReasonEmptySource, move, move.FromX, move.FromY)
+	} else if (entry == Neutrino && !state.IsNeutrinoMove()) ||
+		(entry != Neutrino && (state.IsNeutrinoMove() || entry != state.Player())) {
+		return newMoveError(ReasonWrongTurn, move, move.FromX, move.FromY)
 	}
-	return true, ""
+	return nil
 }
 
-func (self *Controller) isMoveByDirectionLegal(startX, startY byte, direction Direction, steps byte) (answer bool, message string) {
+func (self *Controller) isMoveByDirectionLegal(move Move, direction Direction, steps byte) error {
 
 	if steps == 0 || direction == Origo {
-		return false, "The suggested move does not actually move any piece."
+		return newMoveError(ReasonNoMovement, move, move.FromX, move.FromY)
+	}
+
+	ray := self.getRay(move.FromX, move.FromY, direction)
+	width := self.game.Width()
+	for i := byte(0); i < steps; i++ {
+		//The ray only ends before the destination if the
+		//destination is outside of the board
+		if int(i) >= len(ray) || self.game.occupied&(1<<ray[i]) != 0 {
+			x, y := self.getNthNeighbour(move.FromX, move.FromY, i+1, direction)
+			return newMoveError(ReasonBlockedPath, move, x, y)
+		}
 	}
+	if int(steps) < len(ray) && self.game.occupied&(1<<ray[steps]) == 0 {
+		return newMoveError(ReasonDidNotSlideToObstacle, move, ray[steps]%width, ray[steps]/width)
+	}
+
+	return nil
+}
 
-	for i := byte(1); i <= steps; i++ {
-		//free :=
-		if ! self.checkIfNthNeighbourIsFree(startX, startY, i, direction) {
-			return false, "Invalid move, cannot pass another piece"
+/**
+ * Coordinates that falls outside the board wraps around
+ * the byte and will be rejected by GetLocation, unless
+ * the rules wrap the board around the edges. The neutrino
+ * never wraps around the top and bottom edges, so reaching
+ * a home row still decides the game. Only used for error
+ * messages, moves follow the rays of getRay.
+ */
+func (self *Controller) getNthNeighbour(startX, startY, n byte, direction Direction) (byte, byte) {
+	deltaX, deltaY := getDirectionDelta(direction)
+	x := int(startX) + deltaX*int(n)
+	y := int(startY) + deltaY*int(n)
+	if self.rules.WrapColumns {
+		x = wrapCoordinate(x, self.game.Width())
+	}
+	if self.rules.WrapRows {
+		if entry, _ := self.game.GetLocation(startX, startY); entry != Neutrino {
+			y = wrapCoordinate(y, self.game.Height())
 		}
 	}
-	isNextSquareFree := self.checkIfNthNeighbourIsFree(startX, startY, steps+1, direction)
-	if isNextSquareFree {
-		return false, "Move does not move untill an obstacle is hit"
+	return byte(x), byte(y)
+}
+
+/**
+ * The squares passed when sliding the piece on the
+ * square in the direction, see rayTable
+ */
+func (self *Controller) getRay(x, y byte, direction Direction) []byte {
+	square := x + self.game.Width()*y
+	return self.getRayTable().rays[self.getRayKind(square)][directionIndexes[direction]][square]
+}
+
+func (self *Controller) getRayTable() *rayTable {
+	geometry := geometry{self.game.Width(), self.game.Height(), self.rules.WrapColumns, self.rules.WrapRows}
+	if self.rays == nil || self.rays.geometry != geometry {
+		self.rays = rayTableFor(geometry)
+	}
+	return self.rays
+}
+
+func (self *Controller) getRayKind(square byte) int {
+	if self.game.game[square] == Neutrino {
+		return neutrinoRays
 	}
+	return pieceRays
+}
 
-	return true, ""
+func wrapCoordinate(coordinate int, size byte) int {
+	coordinate %= int(size)
+	if coordinate < 0 {
+		coordinate += int(size)
+	}
+	return coordinate
 }
 
-func (self *Controller) checkIfNthNeighbourIsFree(startX, startY, n byte, direction Direction) bool {
+func getDirectionDelta(direction Direction) (int, int) {
 	switch direction {
 	case N:
-		return self.checkIfSquareIsFree(startX, startY-n)
+		return 0, -1
 	case NE:
-		return self.checkIfSquareIsFree(startX+n, startY-n)
+		return 1, -1
 	case E:
-		return self.checkIfSquareIsFree(startX+n, startY)
+		return 1, 0
 	case SE:
-		return self.checkIfSquareIsFree(startX+n, startY+n)
+		return 1, 1
 	case S:
-		return self.checkIfSquareIsFree(startX, startY+n)
+		return 0, 1
 	case SW:
-		return self.checkIfSquareIsFree(startX-n, startY+n)
+		return -1, 1
 	case W:
-		return self.checkIfSquareIsFree(startX-n, startY)
+		return -1, 0
 	case NW:
-		return self.checkIfSquareIsFree(startX-n, startY-n)
+		return -1, -1
 	default:
 		//Something has gone horrible wrong in the calculations
-		panic(fmt.Sprintf("Game is trying to make a move in an invalid direction", direction))
-	}
-}
-
-func (self *Controller) checkIfSquareIsFree(x, y byte) bool {
-	entry, err := self.game.GetLocation(x, y)
-	if err != nil {
-		return false
-	} else if entry != EmptySquare {
-		return false
+		panic(fmt.Sprintf("Game is trying to make a move in an invalid direction %d", direction))
 	}
-	return true
 }
 
 func (self *Controller) move(move Move) {
@@ -171,100 +505,182 @@ func (self *Controller) move(move Move) {
 	self.game.SetLocation(move.ToX, move.ToY, newEntry)
 }
 
-func (self *Controller) isThereAWinner() (bool, State, error) {
+/**
+ * The game is won when a neutrino reaches a home edge
+ * or when the player about to move next cannot move.
+ * A trapped neutrino is scored by the rules, by default
+ * the player that trapped it wins. In the misère variant
+ * the player moving the neutrino to a home row loses the
+ * game to the next player. With several neutrinos
+ * trapping any one of them decides the game. A player that
+ * cannot move any piece after moving a neutrino loses the
+ * game to the next player.
+ */
+func (self *Controller) isThereAWinner() (bool, State, WinReason, error) {
+
+	neutrinos := self.game.pieces[Neutrino]
+	if neutrinos == 0 {
+		return false, self.game.State, NoWinner, ErrNoNeutrinoInGame
+	}
+
+	player := self.game.State.Player()
+	nextPlayer := self.rules.nextPlayer(player)
+	width := self.game.Width()
+	for neutrinos != 0 {
+		square := byte(bits.TrailingZeros64(neutrinos))
+		neutrinos &= neutrinos - 1
+		if winner, ok := self.getEdgeWinner(square%width, square/width); ok && self.rules.Misere {
+			return true, statesOfPlayer[nextPlayer].win, HomeRowReached, nil
+		} else if ok {
+			return true, statesOfPlayer[winner].win, HomeRowReached, nil
+		}
+	}
 
-	//neutrino location
-	x, y := self.locateNeutrino()
-	if x == 99 || y == 99 {
-		return false, self.game.State, ErrNoNeutrinoInGame
+	//After a piece move the next player must be able to move
+	//every neutrino, and after a neutrino move the same player
+	//must be able to move one of their pieces
+	isPieceMove := !self.game.State.IsNeutrinoMove()
+	if (isPieceMove && !self.isAnyNeutrinoTrapped()) || (!isPieceMove && self.canNextPlayerMove()) {
+		return false, self.game.State, NoWinner, nil
+	}
+	switch {
+	case isPieceMove && self.rules.TrappedNeutrino == TrappedNeutrinoWinsForTrapper && !self.rules.Misere:
+		return true, statesOfPlayer[player].win, NeutrinoTrapped, nil
+	case isPieceMove:
+		return true, statesOfPlayer[nextPlayer].win, NeutrinoTrapped, nil
+	default:
+		return true, statesOfPlayer[nextPlayer].win, OpponentImmobilised, nil
 	}
+}
 
-	//Note that if the neutrino has been move
-	//it cannot be blocked so the only two valid
-	//states where a blocked neutrino could happen
-	//are Player1Move and Player2Move w
-	isSquareBlocked := self.isSquareBlocked(x, y)
-	if isSquareBlocked && self.game.State == Player1Move {
-		return true, Player1Win, nil
-	} else if isSquareBlocked && self.game.State == Player2Move {
-		return true, Player2Win, nil
+/**
+ * The player winning when the neutrino is on the square,
+ * which is the player on the opposite edge of the home edge
+ * the neutrino is on. A corner counts as part of the north
+ * or south edge.
+ */
+func (self *Controller) getEdgeWinner(x, y byte) (Entry, bool) {
+	fourPlayers := self.rules.Players == 4
+	switch {
+	case y == 0:
+		return Player2, true
+	case y == self.game.Height()-1:
+		return Player1, true
+	case fourPlayers && x == self.game.Width()-1:
+		return Player4, true
+	case fourPlayers && x == 0:
+		return Player3, true
+	default:
+		return EmptySquare, false
 	}
+}
+
+/**
+ * Player 2 only moves the neutrino on the first move
+ * of the handicap, which is known by the game being
+ * made by NewHandicapGame and not changed since, or
+ * by the board being the opening, e.g. after an undo.
+ * It does not depend on the moves made through the
+ * controller, so a game can be handed to a new
+ * controller at any time.
+ */
+func (self *Controller) isExtraNeutrinoMove() bool {
+	return self.rules.Handicap == HandicapExtraNeutrinoMove && self.game.State == Player2NeutrinoMove &&
+		(self.game.opening || self.game.hasStandardOpeningBoard())
+}
+
+func (self *Controller) canNextPlayerMove() bool {
+	currentState := self.game.State
+	self.game.State = self.getNextState()
+	canMove := len(self.legalMoves(true)) > 0
+	self.game.State = currentState
+	return canMove
+}
+
+type square struct {
+	x, y byte
+}
 
-	if y == 0 {
-		return true, Player2Win, nil
-	} else if y == 4 {
-		return true, Player1Win, nil
+//Every neutrino on the board, row by row
+func (self *Controller) locateNeutrinos() []square {
+	var neutrinos []square
+	width := self.game.Width()
+	for mask := self.game.pieces[Neutrino]; mask != 0; mask &= mask - 1 {
+		neutrino := byte(bits.TrailingZeros64(mask))
+		neutrinos = append(neutrinos, square{neutrino % width, neutrino / width})
 	}
-	return false, self.game.State, nil
+	return neutrinos
 }
 
-func (self *Controller) locateNeutrino() (byte, byte) {
-	for x := byte(0); x < 5; x++ {
-		for y := byte(0); y < 5; y++ {
-			piece, _ := self.game.GetLocation(x, y)
-			if piece == Neutrino {
-				return x, y
-			}
+func (self *Controller) isAnyNeutrinoTrapped() bool {
+	width := self.game.Width()
+	for mask := self.game.pieces[Neutrino]; mask != 0; mask &= mask - 1 {
+		neutrino := byte(bits.TrailingZeros64(mask))
+		if self.isSquareBlocked(neutrino%width, neutrino/width) {
+			return true
 		}
 	}
-	return 99, 99
+	return false
 }
 
 func (self *Controller) isSquareBlocked(x, y byte) bool {
-	isSquareBlocked := true
-	iStart := -1
-	if x == 0 {
-		iStart = 0
-	}
-	jStart := -1
-	if y == 0 {
-		jStart = 0
-	}
-	for i := iStart; i < 2; i++ {
-		for j := jStart; j < 2; j++ {
-			if i == 0 && j == 0 {
-				continue
-			}
-			piece, err := self.game.GetLocation(byte(int(x)+i), byte(int(y)+j))
-			isNeighbourBlocked := err != nil || piece != EmptySquare
-			isSquareBlocked = isSquareBlocked && isNeighbourBlocked
-		}
-	}
-	return isSquareBlocked
+	square := x + self.game.Width()*y
+	return self.getRayTable().neighbours[self.getRayKind(square)][square]&^self.game.occupied == 0
 }
 
 func (self *Controller) getNextState() State {
-	switch self.game.State {
-	case Player1NeutrinoMove:
-		return Player1Move
-	case Player1Move:
-		return Player2NeutrinoMove
-	case Player2NeutrinoMove:
-		return Player2Move
-	case Player2Move:
+	state := self.game.State
+	player := state.Player()
+	if player == EmptySquare || state.IsWin() {
+		//We should never get here
+		panic(fmt.Sprintf("Game is in a state it cannot move on from %d", state))
+	}
+	if self.extraNeutrinoMove {
 		return Player1NeutrinoMove
+	} else if state.IsNeutrinoMove() {
+		return statesOfPlayer[player].pieceMove
+	}
+	return statesOfPlayer[self.rules.nextPlayer(player)].neutrinoMove
+}
+
+/**
+ * Player 1 and 2 have the north and south edges as
+ * home rows, and in the four player variant player 3
+ * and 4 have the east and west edges
+ */
+func (self *Controller) isOnHomeEdge(player Entry, x, y byte) bool {
+	switch player {
+	case Player1:
+		return y == 0
+	case Player2:
+		return y == self.game.Height()-1
+	case Player3:
+		return x == self.game.Width()-1
+	case Player4:
+		return x == 0
 	default:
-		//We should never get here
-		panic(fmt.Sprintf("Game is in a state it cannot move on from %d", self.game.State))
+		return false
 	}
 }
 
-func (self *Controller) getOwnPiecesOnHomeRow(player byte) byte {
-	count := byte(0)
-	if player == 1 {
-		for i := byte(0); i < 5; i++ {
-			piece, _ := self.game.GetLocation(i, 0)
-			if piece == Player1 {
-				count++
-			}
-		}
-	} else if player == 2 {
-		for i := byte(0); i < 5; i++ {
-			piece, _ := self.game.GetLocation(i, 4)
-			if piece == Player2 {
-				count++
-			}
-		}
+func (self *Controller) getOwnPiecesOnHomeEdge(player Entry) byte {
+	if player >= entryKinds {
+		return 0
+	}
+	return byte(bits.OnesCount64(self.game.pieces[player] & self.getRayTable().homeEdges[player]))
+}
+
+/**
+ * The corners are left empty in the four player variant
+ * and a handicap can take pieces away from player 1
+ */
+func (self *Controller) piecesPerPlayer(player Entry) byte {
+	pieces := self.game.Width()
+	if self.rules.Players == 4 {
+		pieces -= 2
+	}
+	if player == Player1 {
+		pieces -= self.rules.Handicap.removedPieces()
 	}
-	return count
+	return pieces
 }
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+type moveErrorCheck struct {
+	move                 Move
+	expectedError        error
+	expectedX, expectedY byte
+}
+
+func TestMoveErrorReasons(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player1)
+	game.SetLocation(2, 0, Player1)
+	game.SetLocation(3, 0, Player1)
+	game.SetLocation(4, 3, Player1)
+	game.SetLocation(2, 2, Neutrino)
+	game.SetLocation(4, 4, Player2)
+	game.State = Player1Move
+
+	checks := []moveErrorCheck{
+		{NewMove(4, 4, 4, 0), ErrWrongTurn, 4, 4},
+		{NewMove(1, 1, 1, 2), ErrEmptySource, 1, 1},
+		{NewMove(0, 0, 1, 2), ErrNotStraightLine, 1, 2},
+		{NewMove(0, 0, 3, 3), ErrBlockedPath, 2, 2},
+		{NewMove(4, 3, 4, 2), ErrDidNotSlideToObstacle, 4, 1},
+		{NewMove(4, 3, 4, 0), ErrHomeRowRestriction, 4, 0},
+		{NewMove(4, 3, 5, 3), ErrOutOfBounds, 5, 3},
+		{NewMove(5, 3, 4, 3), ErrOutOfBounds, 5, 3},
+		{NewMove(4, 3, 4, 3), ErrNoMovement, 4, 3}}
+
+	for _, check := range checks {
+		_, err := controller.MakeMove(check.move)
+		if !errors.Is(err, check.expectedError) {
+			t.Error("Expected", check.expectedError, "for", check.move, "got", err)
+			continue
+		}
+		var moveError *MoveError
+		if !errors.As(err, &moveError) {
+			t.Error("Expected a MoveError for", check.move, "got", err)
+			continue
+		}
+		if moveError.Move != check.move {
+			t.Error("Expected the error to contain", check.move, "got", moveError.Move)
+		}
+		if moveError.X != check.expectedX || moveError.Y != check.expectedY {
+			t.Error("Expected the error to point at", check.expectedX, check.expectedY, "got", moveError.X, moveError.Y)
+		}
+	}
+}
+
+func TestMoveErrorWhenGameIsOver(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+	game.State = Player2Win
+
+	_, err := controller.MakeMove(NewMove(2, 2, 2, 1))
+	if !errors.Is(err, ErrGameOver) {
+		t.Error("Expected", ErrGameOver, "got", err)
+	}
+	if errors.Is(err, ErrWrongTurn) {
+		t.Error("Expected the error to only match its own reason, got", err)
+	}
+}
+
+func TestNoNeutrinoIsNotAMoveError(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.State = Player2Move
+	game.SetLocation(0, 4, Player2)
+
+	_, err := controller.MakeMove(NewMove(0, 4, 4, 4))
+	if err != ErrNoNeutrinoInGame {
+		t.Error("Expected", ErrNoNeutrinoInGame, "got", err)
+	}
+	var moveError *MoveError
+	if errors.As(err, &moveError) {
+		t.Error("Expected a missing neutrino to not be a move error, got", moveError)
+	}
+}
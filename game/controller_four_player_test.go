@@ -0,0 +1,213 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+/**
+ * Basic setup of an empty 7x7 game
+ * for four players
+ */
+func SetupFourPlayerGame() (*Game, *Controller) {
+	game, _ := NewEmptyGameOfSize(largeBoardSize, largeBoardSize)
+	controller := NewController(FourPlayerRules())
+	controller.PlayGame(game)
+	return game, controller
+}
+
+func TestNewFourPlayerGame(t *testing.T) {
+	game := NewFourPlayerGame()
+
+	counts := map[Entry]int{}
+	for y := byte(0); y < 7; y++ {
+		for x := byte(0); x < 7; x++ {
+			entry, _ := game.GetLocation(x, y)
+			counts[entry]++
+		}
+	}
+	for _, player := range []Entry{Player1, Player2, Player3, Player4} {
+		if counts[player] != 5 {
+			t.Error("Expected player", player, "to have 5 pieces got", counts[player])
+		}
+	}
+	for _, corner := range [][2]byte{{0, 0}, {6, 0}, {0, 6}, {6, 6}} {
+		if entry, _ := game.GetLocation(corner[0], corner[1]); entry != EmptySquare {
+			t.Error("Expected the corner", corner, "to be empty got", entry)
+		}
+	}
+
+	if err := game.ValidateFor(FourPlayerRules()); err != nil {
+		t.Error("Expected the game to be valid, got", err)
+	}
+	if err := game.Validate(); !errors.Is(err, ErrInvalidPosition) {
+		t.Error("Expected", ErrInvalidPosition, "for two players, got", err)
+	}
+	if _, err := NewFourPlayerGameOfSize(5); err == nil {
+		t.Error("Expected an error for a four player board of size 5")
+	}
+}
+
+func TestFourPlayerTurnOrder(t *testing.T) {
+	game := NewFourPlayerGame()
+	controller := NewController(FourPlayerRules())
+	controller.PlayGame(game)
+
+	expectedStates := []State{
+		Player1Move, Player3NeutrinoMove, Player3Move, Player2NeutrinoMove,
+		Player2Move, Player4NeutrinoMove, Player4Move, Player1NeutrinoMove}
+	for _, expected := range expectedStates {
+		state, err := controller.MakeMove(firstMoveWithoutWinner(game, controller, t))
+		if err != nil {
+			t.Fatal("Expected no error got", err)
+		}
+		if state != expected {
+			t.Fatal("Expected", expected, "got", state)
+		}
+	}
+}
+
+func firstMoveWithoutWinner(game *Game, controller *Controller, t *testing.T) Move {
+	for _, move := range controller.LegalMoves() {
+		copyOfGame := *game
+		copyController := NewController(controller.Rules())
+		copyController.PlayGame(&copyOfGame)
+		if state, _ := copyController.MakeMove(move); !state.IsWin() {
+			return move
+		}
+	}
+	t.Fatal("Expected a move that does not end the game")
+	return Move{}
+}
+
+func TestFourPlayerNeutrinoReachesEdge(t *testing.T) {
+	edges := map[Move]State{
+		NewMove(3, 3, 3, 0): Player2Win,
+		NewMove(3, 3, 3, 6): Player1Win,
+		NewMove(3, 3, 6, 3): Player4Win,
+		NewMove(3, 3, 0, 3): Player3Win}
+
+	for move, expected := range edges {
+		game, controller := SetupFourPlayerGame()
+		game.SetLocation(3, 3, Neutrino)
+		game.State = Player3NeutrinoMove
+
+		state, err := controller.MakeMove(move)
+		if err != nil {
+			t.Error("Expected no error got", err)
+		}
+		if state != expected || game.WinReason != HomeRowReached {
+			t.Error("Moving", move, "expected", expected, "by", HomeRowReached, "got", state, "by", game.WinReason)
+		}
+		if err := game.ValidateFor(FourPlayerRules()); err != nil {
+			t.Error("Expected the win to be valid, got", err)
+		}
+	}
+}
+
+func TestFourPlayerImmobilisedLosesToNextPlayer(t *testing.T) {
+	game, controller := SetupFourPlayerGame()
+	game.SetLocation(3, 0, Player1)
+	game.SetLocation(3, 3, Neutrino)
+	game.State = Player3NeutrinoMove
+
+	//Player 3 has no pieces to move
+	state, err := controller.MakeMove(NewMove(3, 3, 3, 1))
+	if err != nil {
+		t.Error("Expected no error got", err)
+	}
+	if state != Player2Win || game.WinReason != OpponentImmobilised {
+		t.Error("Expected", Player2Win, "by", OpponentImmobilised, "got", state, "by", game.WinReason)
+	}
+	if err := game.ValidateFor(FourPlayerRules()); err != nil {
+		t.Error("Expected the win to be valid, got", err)
+	}
+}
+
+func TestFourPlayerTrappedNeutrino(t *testing.T) {
+	scorings := map[TrappedNeutrinoScoring]State{
+		TrappedNeutrinoWinsForTrapper:  Player3Win,
+		TrappedNeutrinoLosesForTrapper: Player2Win}
+
+	for scoring, expected := range scorings {
+		game, _ := SetupFourPlayerGame()
+		rules := FourPlayerRules()
+		rules.TrappedNeutrino = scoring
+		controller := NewController(rules)
+		controller.PlayGame(game)
+
+		game.SetLocation(2, 0, Player1)
+		game.SetLocation(3, 0, Player1)
+		game.SetLocation(4, 0, Player1)
+		game.SetLocation(2, 1, Player4)
+		game.SetLocation(2, 2, Player2)
+		game.SetLocation(3, 2, Player2)
+		game.SetLocation(4, 2, Player2)
+		game.SetLocation(6, 1, Player3)
+		game.SetLocation(3, 1, Neutrino)
+		game.State = Player3Move
+
+		state, err := controller.MakeMove(NewMove(6, 1, 4, 1))
+		if err != nil {
+			t.Error("Expected no error got", err)
+		}
+		if state != expected || game.WinReason != NeutrinoTrapped {
+			t.Error("Expected", expected, "by", NeutrinoTrapped, "got", state, "by", game.WinReason)
+		}
+	}
+}
+
+func TestFourPlayerHomeEdgeRestriction(t *testing.T) {
+	game, controller := SetupFourPlayerGame()
+	game.SetLocation(6, 1, Player3)
+	game.SetLocation(6, 2, Player3)
+	game.SetLocation(6, 4, Player3)
+	game.SetLocation(6, 5, Player3)
+	game.SetLocation(5, 3, Player3)
+	game.SetLocation(3, 5, Neutrino)
+	game.State = Player3Move
+
+	_, err := controller.MakeMove(NewMove(5, 3, 6, 3))
+	if !errors.Is(err, ErrHomeRowRestriction) {
+		t.Error("Expected", ErrHomeRowRestriction, "got", err)
+	}
+}
+
+func TestFourPlayerGameText(t *testing.T) {
+	game := NewFourPlayerGame()
+	text, err := game.MarshalText()
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if string(text) != "1xxxxx1/z5y/z5y/z2n2y/z5y/z5y/1ooooo1 1n" {
+		t.Error("Unexpected text for the four player game", string(text))
+	}
+
+	readGame := &Game{}
+	if err := readGame.UnmarshalText(text); err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if isEqual, difference := Compare(game, readGame); !isEqual {
+		t.Error("Expected the same game after reading the text got", difference)
+	}
+}
+
+func TestTwoPlayerControllerWithStateOfPlayer3(t *testing.T) {
+	for _, state := range []State{Player3NeutrinoMove, Player3Move, Player4NeutrinoMove, Player4Move} {
+		game := NewStandardGame()
+		game.State = state
+		controller := NewController(StandardRules())
+		controller.PlayGame(game)
+
+		move := NewMove(2, 2, 2, 1)
+		if state == Player3Move || state == Player4Move {
+			move = NewMove(0, 0, 0, 3)
+		}
+		if _, err := controller.MakeMove(move); !errors.Is(err, ErrWrongTurn) {
+			t.Error("Expected", ErrWrongTurn, "in state", state, "got", err)
+		}
+		if moves := controller.LegalMoves(); moves != nil {
+			t.Error("Expected no legal moves in state", state, "got", moves)
+		}
+	}
+}
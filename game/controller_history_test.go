@@ -0,0 +1,138 @@
+package game
+
+import "testing"
+
+func TestUndoRestoresBoardAndState(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+	reference := NewStandardGame()
+
+	makeMoveAndCheckError(2, 2, 3, 3, controller, t)
+	makeMoveAndCheckError(3, 0, 3, 2, controller, t)
+
+	if err := controller.Undo(); err != nil {
+		t.Error("Expected to be able to undo, got", err)
+	}
+	if err := controller.Undo(); err != nil {
+		t.Error("Expected to be able to undo, got", err)
+	}
+	if result, message := Compare(reference, game); !result {
+		t.Error("Expected the game to be back at the start: ", message)
+	}
+	if controller.Ply() != 0 {
+		t.Error("Expected ply", 0, "got", controller.Ply())
+	}
+}
+
+func TestUndoWithoutMovesGivesError(t *testing.T) {
+	_, controller := SetupCenteredGame()
+
+	if err := controller.Undo(); err != ErrNothingToUndo {
+		t.Error("Expected", ErrNothingToUndo, "got", err)
+	}
+}
+
+func TestRedoWithoutUndoGivesError(t *testing.T) {
+	_, controller := SetupCenteredGame()
+
+	makeMoveAndCheckError(2, 2, 2, 0, controller, t)
+	if err := controller.Redo(); err != ErrNothingToRedo {
+		t.Error("Expected", ErrNothingToRedo, "got", err)
+	}
+}
+
+func TestRedoReplaysUndoneMoves(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	makeMoveAndCheckError(2, 2, 3, 3, controller, t)
+	makeMoveAndCheckError(3, 0, 3, 2, controller, t)
+	reference := UInt64ToGame(GameToUInt64(game))
+
+	controller.Undo()
+	controller.Undo()
+	if err := controller.Redo(); err != nil {
+		t.Error("Expected to be able to redo, got", err)
+	}
+	if err := controller.Redo(); err != nil {
+		t.Error("Expected to be able to redo, got", err)
+	}
+	if result, message := Compare(reference, game); !result {
+		t.Error("Expected the redone game to match the played game: ", message)
+	}
+	if controller.Ply() != 2 {
+		t.Error("Expected ply", 2, "got", controller.Ply())
+	}
+}
+
+func TestUndoLeavesWinState(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(2, 1, Neutrino)
+	game.State = Player1NeutrinoMove
+	makeMoveAndCheckError(2, 1, 2, 4, controller, t)
+	if game.State != Player1Win {
+		t.Error("Expected", Player1Win, "got", game.State)
+	}
+
+	controller.Undo()
+	if game.State != Player1NeutrinoMove {
+		t.Error("Expected", Player1NeutrinoMove, "after undo got", game.State)
+	}
+	neutrino, _ := game.GetLocation(2, 1)
+	if neutrino != Neutrino {
+		t.Error("Expected", Neutrino, "at (2, 1) got", neutrino)
+	}
+
+	controller.Redo()
+	if game.State != Player1Win {
+		t.Error("Expected", Player1Win, "after redo got", game.State)
+	}
+}
+
+func TestNewMoveDiscardsRedo(t *testing.T) {
+	_, controller := SetupCenteredGame()
+
+	makeMoveAndCheckError(2, 2, 2, 0, controller, t)
+	controller.Undo()
+	makeMoveAndCheckError(2, 2, 4, 2, controller, t)
+
+	if err := controller.Redo(); err != ErrNothingToRedo {
+		t.Error("Expected", ErrNothingToRedo, "got", err)
+	}
+	history := controller.History()
+	if len(history) != 1 || history[0] != NewMove(2, 2, 4, 2) {
+		t.Error("Expected history to only contain", NewMove(2, 2, 4, 2), "got", history)
+	}
+}
+
+func TestHistoryFollowsUndo(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	makeMoveAndCheckError(2, 2, 3, 3, controller, t)
+	makeMoveAndCheckError(3, 0, 3, 2, controller, t)
+	expectedHistory := []Move{NewMove(2, 2, 3, 3), NewMove(3, 0, 3, 2)}
+	history := controller.History()
+	if len(history) != len(expectedHistory) || history[0] != expectedHistory[0] || history[1] != expectedHistory[1] {
+		t.Error("Expected history", expectedHistory, "got", history)
+	}
+
+	controller.Undo()
+	history = controller.History()
+	if len(history) != 1 || history[0] != expectedHistory[0] {
+		t.Error("Expected history", expectedHistory[:1], "got", history)
+	}
+}
+
+func TestInvalidMoveIsNotRecorded(t *testing.T) {
+	_, controller := SetupCenteredGame()
+
+	controller.MakeMove(NewMove(2, 2, 2, 2))
+	if controller.Ply() != 0 || len(controller.History()) != 0 {
+		t.Error("Expected an invalid move to not be part of the history, got", controller.History())
+	}
+}
@@ -0,0 +1,72 @@
+package game
+
+import "testing"
+
+func TestLargeGameNeutrinoReachesLastRow(t *testing.T) {
+	game := NewLargeGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+	game.SetLocation(3, 6, EmptySquare)
+
+	state, err := controller.MakeMove(NewMove(3, 3, 3, 6))
+	if err != nil {
+		t.Error("Expected to be able to move the neutrino to the last row, got", err)
+	}
+	if state != Player1Win {
+		t.Error("Expected", Player1Win, "got", state)
+	}
+}
+
+func TestLargeGameLegalMovesMatchesMakeMove(t *testing.T) {
+	game := NewLargeGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	var acceptedMoves []Move
+	for from := byte(0); from < 49; from++ {
+		for to := byte(0); to < 49; to++ {
+			copyOfGame := *game
+			copyController := &Controller{}
+			copyController.PlayGame(&copyOfGame)
+			move := NewMove(from%7, from/7, to%7, to/7)
+			if _, err := copyController.MakeMove(move); err == nil {
+				acceptedMoves = append(acceptedMoves, move)
+			}
+		}
+	}
+	checkLegalMoves(acceptedMoves, controller.LegalMoves(), t)
+	if len(acceptedMoves) != 8 {
+		t.Error("Expected the neutrino to have", 8, "moves got", acceptedMoves)
+	}
+}
+
+func TestCannotMoveAllSevenPiecesBackToHomeRow(t *testing.T) {
+	game := NewLargeGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	game.SetLocation(1, 0, EmptySquare)
+	game.SetLocation(1, 5, Player1)
+	game.State = Player1Move
+	if _, err := controller.MakeMove(NewMove(1, 5, 1, 0)); err == nil {
+		t.Error("Expected to not be able to return all seven pieces to home row")
+	}
+
+	game.SetLocation(6, 0, EmptySquare)
+	game.State = Player1Move
+	if _, err := controller.MakeMove(NewMove(1, 5, 1, 0)); err != nil {
+		t.Error("Expected to be able to return a sixth piece to home row, got", err)
+	}
+}
+
+func TestLargeGameIsValid(t *testing.T) {
+	if err := NewLargeGame().Validate(); err != nil {
+		t.Error("Expected the large game to be valid, got", err)
+	}
+}
+
+func TestLargeGameCannotBeSerialized(t *testing.T) {
+	if _, err := EncodeGame(NewLargeGame()); err == nil {
+		t.Error("Expected an error when serializing a 7x7 game")
+	}
+}
@@ -0,0 +1,168 @@
+package game
+
+import "testing"
+
+func TestLegalMovesFromStandardGame(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	expectedMoves := []Move{
+		NewMove(2, 2, 2, 1),
+		NewMove(2, 2, 3, 1),
+		NewMove(2, 2, 4, 2),
+		NewMove(2, 2, 3, 3),
+		NewMove(2, 2, 2, 3),
+		NewMove(2, 2, 1, 3),
+		NewMove(2, 2, 0, 2),
+		NewMove(2, 2, 1, 1)}
+
+	checkLegalMoves(expectedMoves, controller.LegalMoves(), t)
+}
+
+func TestLegalMovesForPlayerPieces(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(2, 2, Neutrino)
+	game.SetLocation(4, 4, Player2)
+	game.State = Player1Move
+
+	expectedMoves := []Move{
+		NewMove(0, 0, 4, 0),
+		NewMove(0, 0, 1, 1),
+		NewMove(0, 0, 0, 4)}
+
+	checkLegalMoves(expectedMoves, controller.LegalMoves(), t)
+}
+
+func TestLegalMovesHonourHomeRowRestriction(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player1)
+	game.SetLocation(2, 0, Player1)
+	game.SetLocation(4, 0, Player1)
+	game.SetLocation(3, 4, Player1)
+	game.SetLocation(1, 2, Neutrino)
+	game.State = Player1Move
+
+	for _, move := range controller.LegalMoves() {
+		if move.FromX == 3 && move.FromY == 4 && move.ToY == 0 {
+			t.Error("Expected no move of the fifth piece back to the home row, got", move)
+		}
+	}
+}
+
+func TestNoLegalMovesWhenGameIsWon(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	for _, state := range []State{Player1Win, Player2Win} {
+		game.State = state
+		if moves := controller.LegalMoves(); len(moves) != 0 {
+			t.Error("Expected no legal moves in state", state, "got", moves)
+		}
+	}
+}
+
+/**
+ * Compare the generated moves with every move
+ * MakeMove accepts on a copy of the board
+ */
+func TestLegalMovesMatchesMakeMove(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+	checkLegalMovesAgainstMakeMove(game, controller, t)
+
+	makeMoveAndCheckError(2, 2, 3, 3, controller, t)
+	checkLegalMovesAgainstMakeMove(game, controller, t)
+	makeMoveAndCheckError(3, 0, 3, 2, controller, t)
+	checkLegalMovesAgainstMakeMove(game, controller, t)
+	makeMoveAndCheckError(3, 3, 4, 3, controller, t)
+	checkLegalMovesAgainstMakeMove(game, controller, t)
+	makeMoveAndCheckError(2, 4, 4, 2, controller, t)
+	checkLegalMovesAgainstMakeMove(game, controller, t)
+}
+
+func checkLegalMovesAgainstMakeMove(game *Game, controller *Controller, t *testing.T) {
+	var acceptedMoves []Move
+	width, squares := game.Width(), game.Width()*game.Height()
+	for from := byte(0); from < squares; from++ {
+		for to := byte(0); to < squares; to++ {
+			copyOfGame := *game
+			copyController := NewController(controller.Rules())
+			copyController.PlayGame(&copyOfGame)
+			move := NewMove(from%width, from/width, to%width, to/width)
+			if _, err := copyController.MakeMove(move); err == nil {
+				acceptedMoves = append(acceptedMoves, move)
+			}
+		}
+	}
+	checkLegalMoves(acceptedMoves, controller.LegalMoves(), t)
+}
+
+func checkLegalMoves(expectedMoves, actualMoves []Move, t *testing.T) {
+	if len(expectedMoves) != len(actualMoves) {
+		t.Error("Expected", len(expectedMoves), "legal moves", expectedMoves, "got", len(actualMoves), actualMoves)
+		return
+	}
+	for _, expected := range expectedMoves {
+		found := false
+		for _, actual := range actualMoves {
+			if expected == actual {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("Expected", expected, "to be a legal move, got", actualMoves)
+		}
+	}
+}
+
+func BenchmarkLegalMoves(b *testing.B) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+	for _, move := range realGameMoves[:4] {
+		controller.MakeMove(move)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		controller.LegalMoves()
+	}
+}
+
+func BenchmarkMakeMoveAndUndo(b *testing.B) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		controller.MakeMove(realGameMoves[0])
+		controller.Undo()
+	}
+}
+
+func BenchmarkPlayRealGame(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		controller := NewController(StandardRules())
+		controller.PlayGame(NewStandardGame())
+		for _, move := range realGameMoves {
+			controller.MakeMove(move)
+		}
+	}
+}
+
+func BenchmarkTorusLegalMoves(b *testing.B) {
+	game := NewStandardGame()
+	controller := NewController(TorusRules(true))
+	controller.PlayGame(game)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		controller.LegalMoves()
+	}
+}
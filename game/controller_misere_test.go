@@ -0,0 +1,85 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func SetupMisereGame() (*Game, *Controller) {
+	game := NewEmptyGame()
+	controller := NewController(MisereRules())
+	controller.PlayGame(game)
+	return game, controller
+}
+
+func TestMisereNeutrinoOnHomeRowLosesForMover(t *testing.T) {
+	states := map[State]State{
+		Player1NeutrinoMove: Player2Win,
+		Player2NeutrinoMove: Player1Win}
+
+	for before, expected := range states {
+		for _, toY := range []byte{0, 4} {
+			game, controller := SetupMisereGame()
+			game.SetLocation(2, 2, Neutrino)
+			game.State = before
+
+			state, err := controller.MakeMove(NewMove(2, 2, 2, toY))
+			if err != nil {
+				t.Error("Expected no error got", err)
+			}
+			if state != expected || game.WinReason != HomeRowReached {
+				t.Error("Moving to row", toY+1, "in", before, "expected", expected, "by", HomeRowReached, "got", state, "by", game.WinReason)
+			}
+			if err := game.ValidateFor(MisereRules()); err != nil {
+				t.Error("Expected the win to be valid, got", err)
+			}
+		}
+	}
+}
+
+func TestMisereTrappedNeutrinoLosesForTrapper(t *testing.T) {
+	for _, scoring := range []TrappedNeutrinoScoring{TrappedNeutrinoWinsForTrapper, TrappedNeutrinoLosesForTrapper} {
+		game := NewEmptyGame()
+		controller := NewController(Rules{Misere: true, TrappedNeutrino: scoring})
+		controller.PlayGame(game)
+
+		game.SetLocation(0, 0, Player1)
+		game.SetLocation(1, 0, Player1)
+		game.SetLocation(1, 1, Player1)
+		game.SetLocation(0, 2, Player1)
+		game.SetLocation(1, 4, Player1)
+		game.SetLocation(4, 4, Player2)
+		game.SetLocation(0, 1, Neutrino)
+		game.State = Player1Move
+
+		state, err := controller.MakeMove(NewMove(1, 4, 1, 2))
+		if err != nil {
+			t.Error("It should have been possible to make a move but got", err)
+		}
+		if state != Player2Win || game.WinReason != NeutrinoTrapped {
+			t.Error("Expected", Player2Win, "by", NeutrinoTrapped, "got", state, "by", game.WinReason)
+		}
+	}
+}
+
+func TestMisereWinIsInvalidForStandardRules(t *testing.T) {
+	game, controller := SetupMisereGame()
+	game.SetLocation(2, 2, Neutrino)
+
+	controller.MakeMove(NewMove(2, 2, 2, 4))
+	if err := game.Validate(); !errors.Is(err, ErrInvalidPosition) {
+		t.Error("Expected", ErrInvalidPosition, "got", err)
+	}
+}
+
+func TestMisereReplayRealGame(t *testing.T) {
+	record := &Record{Moves: realGameMoves}
+
+	game, err := record.ReplayWithRules(MisereRules())
+	if err != nil {
+		t.Error("Expected to be able to replay the record, got", err)
+	}
+	if game.State != Player1Win {
+		t.Error("Expected the trapper to lose,", Player1Win, "got", game.State)
+	}
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewMultiNeutrinoGame(t *testing.T) {
+	game, err := NewMultiNeutrinoGame(3)
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	for x := byte(0); x < 5; x++ {
+		expected := EmptySquare
+		if x%2 == 0 {
+			expected = Neutrino
+		}
+		if entry, _ := game.GetLocation(x, 2); entry != expected {
+			t.Error("Expected", expected, "at", x, "got", entry)
+		}
+	}
+	if err := game.ValidateFor(MultiNeutrinoRules(3)); err != nil {
+		t.Error("Expected the game to be valid, got", err)
+	}
+	if err := game.Validate(); !errors.Is(err, ErrInvalidPosition) {
+		t.Error("Expected", ErrInvalidPosition, "for the standard rules, got", err)
+	}
+
+	for _, neutrinos := range []byte{0, 6} {
+		if _, err := NewMultiNeutrinoGame(neutrinos); err == nil {
+			t.Error("Expected an error for", neutrinos, "neutrinos")
+		}
+	}
+}
+
+func TestMoveAnyNeutrino(t *testing.T) {
+	for _, move := range []Move{NewMove(1, 2, 1, 1), NewMove(3, 2, 3, 1)} {
+		game, _ := NewMultiNeutrinoGame(2)
+		controller := NewController(MultiNeutrinoRules(2))
+		controller.PlayGame(game)
+
+		state, err := controller.MakeMove(move)
+		if err != nil {
+			t.Error("Expected no error moving", move, "got", err)
+		}
+		if state != Player1Move {
+			t.Error("Expected", Player1Move, "got", state)
+		}
+	}
+}
+
+func TestLegalMovesForEveryNeutrino(t *testing.T) {
+	game, controller := SetupSquaredGame()
+	checkLegalMovesAgainstMakeMove(game, controller, t)
+	if moves := controller.LegalMoves(); len(moves) != 32 {
+		t.Error("Expected 8 moves for each of the 4 neutrinos, got", len(moves))
+	}
+}
+
+func TestAnyNeutrinoReachingHomeRowWins(t *testing.T) {
+	game, controller := SetupSquaredGame()
+
+	state, err := controller.MakeMove(NewMove(3, 3, 3, 4))
+	if err != nil {
+		t.Error("Expected no error got", err)
+	}
+	if state != Player1Win || game.WinReason != HomeRowReached {
+		t.Error("Expected", Player1Win, "by", HomeRowReached, "got", state, "by", game.WinReason)
+	}
+
+	game, controller = SetupSquaredGame()
+	state, _ = controller.MakeMove(NewMove(1, 1, 1, 0))
+	if state != Player2Win || game.WinReason != HomeRowReached {
+		t.Error("Expected", Player2Win, "by", HomeRowReached, "got", state, "by", game.WinReason)
+	}
+}
+
+func TestTrappingOneOfSeveralNeutrinos(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player1)
+	game.SetLocation(1, 1, Player1)
+	game.SetLocation(0, 2, Player1)
+	game.SetLocation(1, 4, Player1)
+	game.SetLocation(4, 4, Player2)
+	game.SetLocation(0, 1, Neutrino)
+	game.SetLocation(3, 2, Neutrino)
+	game.State = Player1Move
+
+	state, err := controller.MakeMove(NewMove(1, 4, 1, 2))
+	if err != nil {
+		t.Error("It should have been possible to make a move but got", err)
+	}
+	if state != Player1Win || game.WinReason != NeutrinoTrapped {
+		t.Error("Expected", Player1Win, "by", NeutrinoTrapped, "got", state, "by", game.WinReason)
+	}
+	if err := game.ValidateFor(MultiNeutrinoRules(2)); err != nil {
+		t.Error("Expected the trapped win to be valid, got", err)
+	}
+}
+
+func TestNoNeutrinoTrappedWithSeveralNeutrinos(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player1)
+	game.SetLocation(0, 2, Player1)
+	game.SetLocation(1, 4, Player1)
+	game.SetLocation(4, 4, Player2)
+	game.SetLocation(0, 1, Neutrino)
+	game.SetLocation(3, 2, Neutrino)
+	game.State = Player1Move
+
+	//(1, 2) is still free so both neutrinos can move
+	state, err := controller.MakeMove(NewMove(1, 4, 1, 1))
+	if err != nil {
+		t.Error("It should have been possible to make a move but got", err)
+	}
+	if state != Player2NeutrinoMove {
+		t.Error("Expected", Player2NeutrinoMove, "got", state)
+	}
+}
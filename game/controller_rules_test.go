@@ -0,0 +1,158 @@
+package game
+
+import "testing"
+
+func TestStandardRulesStartWithNeutrinoMove(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+
+	if game.State != Player1NeutrinoMove {
+		t.Error("Expected", Player1NeutrinoMove, "got", game.State)
+	}
+}
+
+func TestSkipFirstNeutrinoMove(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(OfficialRules())
+	controller.PlayGame(game)
+
+	if game.State != Player1Move {
+		t.Error("Expected", Player1Move, "got", game.State)
+	}
+	state, err := controller.MakeMove(NewMove(0, 0, 0, 3))
+	if err != nil {
+		t.Error("Expected player 1 to be able to move a piece first, got", err)
+	}
+	if state != Player2NeutrinoMove {
+		t.Error("Expected", Player2NeutrinoMove, "got", state)
+	}
+}
+
+func TestSkipFirstNeutrinoMoveOnlyInOpening(t *testing.T) {
+	game, controller := SetupCenteredGame()
+	controller = NewController(OfficialRules())
+	controller.PlayGame(game)
+
+	if game.State != Player1NeutrinoMove {
+		t.Error("Expected a game not in the opening position to keep", Player1NeutrinoMove, "got", game.State)
+	}
+}
+
+func TestSkipFirstNeutrinoMoveInVariants(t *testing.T) {
+	handicapGame, _ := NewHandicapGame(HandicapOnePiece)
+	multiNeutrinoGame, _ := NewMultiNeutrinoGame(2)
+	randomGame, err := NewRandomGame(1, RandomGameOptions{Rules: OfficialRules()})
+	if err != nil {
+		t.Fatal("Expected a random game got", err)
+	}
+	games := map[string]*Game{
+		"handicap":       handicapGame,
+		"multi neutrino": multiNeutrinoGame,
+		"four player":    NewFourPlayerGame(),
+		"random":         randomGame,
+	}
+	rules := map[string]Rules{
+		"handicap":       HandicapRules(HandicapOnePiece),
+		"multi neutrino": MultiNeutrinoRules(2),
+		"four player":    FourPlayerRules(),
+		"random":         StandardRules(),
+	}
+
+	for name, game := range games {
+		variantRules := rules[name]
+		variantRules.SkipFirstNeutrinoMove = true
+		controller := NewController(variantRules)
+		controller.PlayGame(game)
+		if game.State != Player1Move {
+			t.Error("Expected the", name, "game to start in", Player1Move, "got", game.State)
+		}
+		for _, move := range controller.LegalMoves() {
+			entry, _ := game.GetLocation(move.FromX, move.FromY)
+			if entry != Player1 {
+				t.Error("Expected only piece moves of player 1 in the", name, "game got", move)
+			}
+		}
+	}
+}
+
+func TestSkipFirstNeutrinoMoveNotAfterSetLocation(t *testing.T) {
+	game := NewFourPlayerGame()
+	game.SetLocation(0, 0, Player1)
+	controller := NewController(FourPlayerRules())
+	controller.rules.SkipFirstNeutrinoMove = true
+	controller.PlayGame(game)
+
+	if game.State != Player1NeutrinoMove {
+		t.Error("Expected a changed board to keep", Player1NeutrinoMove, "got", game.State)
+	}
+}
+
+func TestRandomGameWithOfficialRules(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		game, err := NewRandomGame(seed, RandomGameOptions{Rules: OfficialRules()})
+		if err != nil {
+			t.Fatal("Expected a random game for seed", seed, "got", err)
+		}
+		controller := NewController(OfficialRules())
+		controller.PlayGame(game)
+		if game.State != Player1Move || canWinThisTurn(controller) {
+			t.Error("Expected player 1 to start by moving a piece without winning for seed", seed)
+		}
+	}
+}
+
+func TestAllowFullHomeRow(t *testing.T) {
+	game, _ := SetupEmptyGame()
+	controller := NewController(Rules{AllowFullHomeRow: true})
+	controller.PlayGame(game)
+
+	game.State = Player1Move
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player1)
+	game.SetLocation(2, 0, Player1)
+	game.SetLocation(4, 0, Player1)
+	game.SetLocation(3, 4, Player1)
+	game.SetLocation(1, 2, Neutrino)
+
+	if _, err := controller.MakeMove(NewMove(3, 4, 3, 0)); err != nil {
+		t.Error("Expected to be able to move all pieces back on the home row, got", err)
+	}
+}
+
+func TestTrappedNeutrinoLosesForTrapper(t *testing.T) {
+	game, _ := SetupEmptyGame()
+	controller := NewController(Rules{TrappedNeutrino: TrappedNeutrinoLosesForTrapper})
+	controller.PlayGame(game)
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player1)
+	game.SetLocation(0, 2, Player2)
+	game.SetLocation(1, 2, Player2)
+	game.SetLocation(0, 1, Neutrino)
+	game.SetLocation(4, 1, Player1)
+	game.State = Player1Move
+
+	state, err := controller.MakeMove(NewMove(4, 1, 1, 1))
+	if err != nil {
+		t.Error("It should have been possible to make a move but got", err)
+	}
+	if state != Player2Win || game.WinReason != NeutrinoTrapped {
+		t.Error("Expected", Player2Win, "by", NeutrinoTrapped, "got", state, "by", game.WinReason)
+	}
+}
+
+func TestReplayWithRules(t *testing.T) {
+	record := &Record{Moves: []Move{NewMove(0, 0, 0, 3), NewMove(2, 2, 2, 1)}}
+
+	if _, err := record.Replay(); err == nil {
+		t.Error("Expected the record to be illegal with the standard rules")
+	}
+	game, err := record.ReplayWithRules(OfficialRules())
+	if err != nil {
+		t.Error("Expected the record to be legal with the official rules, got", err)
+	}
+	if game.State != Player2Move {
+		t.Error("Expected", Player2Move, "got", game.State)
+	}
+}
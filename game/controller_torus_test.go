@@ -0,0 +1,259 @@
+package game
+
+import "testing"
+
+/**
+ * Basic setup of an empty game on a board
+ * that wraps around the edges
+ */
+func SetupTorusGame(wrapRows bool) (*Game, *Controller) {
+	game := NewEmptyGame()
+	controller := NewController(TorusRules(wrapRows))
+	controller.PlayGame(game)
+	return game, controller
+}
+
+/**
+ * Series of test to see if pieces wrap
+ * around the left and right edges
+ */
+func TestTorusMoveEastWraps(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(3, 2, Player1)
+	game.SetLocation(1, 2, Player2)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	_, moveError := controller.MakeMove(NewMove(3, 2, 0, 2))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+	east, _ := game.GetLocation(0, 2)
+	if east != Player1 {
+		t.Error("Expected", Player1, "got", east)
+	}
+}
+
+func TestTorusMoveWestWraps(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(1, 2, Player1)
+	game.SetLocation(3, 2, Player2)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	_, moveError := controller.MakeMove(NewMove(1, 2, 4, 2))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+	west, _ := game.GetLocation(4, 2)
+	if west != Player1 {
+		t.Error("Expected", Player1, "got", west)
+	}
+}
+
+func TestTorusMoveAroundEmptyRowStopsBehindItself(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(2, 2, Player1)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	_, moveError := controller.MakeMove(NewMove(2, 2, 1, 2))
+	if moveError != nil {
+		t.Error("Expected to slide around the board until the start square, got", moveError)
+	}
+}
+
+func TestTorusMoveNorthEastWraps(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(3, 3, Player1)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	//Passes (4, 2) and wraps to (0, 1) before stopping at the top edge
+	_, moveError := controller.MakeMove(NewMove(3, 3, 1, 0))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+	ne, _ := game.GetLocation(1, 0)
+	if ne != Player1 {
+		t.Error("Expected", Player1, "got", ne)
+	}
+}
+
+func TestTorusMoveSouthWestWraps(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(1, 1, Player1)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	//Passes (0, 2) and wraps to (4, 3) before stopping at the bottom edge
+	_, moveError := controller.MakeMove(NewMove(1, 1, 3, 4))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+	sw, _ := game.GetLocation(3, 4)
+	if sw != Player1 {
+		t.Error("Expected", Player1, "got", sw)
+	}
+}
+
+func TestTorusWithoutWrapRowsStopsAtTopAndBottom(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(2, 2, Player1)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	_, moveError := controller.MakeMove(NewMove(2, 2, 2, 0))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+}
+
+/**
+ * Series of tests for wrapping around
+ * the top and bottom edges
+ */
+func TestTorusMoveNorthWrapsWithWrapRows(t *testing.T) {
+	game, controller := SetupTorusGame(true)
+
+	game.SetLocation(2, 1, Player1)
+	game.SetLocation(2, 3, Player2)
+	game.SetLocation(0, 2, Neutrino)
+	game.State = Player1Move
+
+	_, moveError := controller.MakeMove(NewMove(2, 1, 2, 4))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+	north, _ := game.GetLocation(2, 4)
+	if north != Player1 {
+		t.Error("Expected", Player1, "got", north)
+	}
+}
+
+func TestTorusNeutrinoDoesNotWrapRows(t *testing.T) {
+	game, controller := SetupTorusGame(true)
+
+	game.SetLocation(2, 2, Neutrino)
+	game.State = Player1NeutrinoMove
+
+	_, moveError := controller.MakeMove(NewMove(2, 2, 2, 1))
+	if moveError == nil {
+		t.Error("Expected the neutrino to not stop before the top edge")
+	}
+	state, moveError := controller.MakeMove(NewMove(2, 2, 2, 0))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+	if state != Player2Win {
+		t.Error("Expected the neutrino on the home row to decide the game,", Player2Win, "got", state)
+	}
+}
+
+/**
+ * Series of tests to make sure a piece cannot
+ * jump over another piece or stop before an
+ * obstacle when wrapping
+ */
+func TestTorusStopOnPieceAcrossEdge(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(3, 2, Player1)
+	game.SetLocation(0, 2, Player2)
+	game.SetLocation(2, 2, Player2)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	//Make invalid move past a piece
+	_, moveError := controller.MakeMove(NewMove(3, 2, 1, 2))
+	if moveError == nil {
+		t.Error("Expected move error for moving past a piece")
+	}
+	//Make a move that stops on contact with a piece
+	_, moveError = controller.MakeMove(NewMove(3, 2, 4, 2))
+	if moveError != nil {
+		t.Error("Expected no error got", moveError)
+	}
+}
+
+func TestTorusCannotStopBeforeObstacleAcrossEdge(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(3, 2, Player1)
+	game.SetLocation(1, 2, Player2)
+	game.SetLocation(0, 0, Neutrino)
+	game.State = Player1Move
+
+	_, moveError := controller.MakeMove(NewMove(3, 2, 4, 2))
+	if moveError == nil {
+		t.Error("Expected an error when stopping a piece at the edge before an obstacle")
+	}
+}
+
+/**
+ * Tests to make sure the neutrino is
+ * only trapped when all neighbours across
+ * the edges are occupied
+ */
+func TestTorusNeutrinoOnEdgeIsNotTrapped(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(0, 1, Player1)
+	game.SetLocation(1, 1, Player1)
+	game.SetLocation(1, 2, Player1)
+	game.SetLocation(0, 3, Player2)
+	game.SetLocation(4, 3, Player2)
+	game.SetLocation(0, 2, Neutrino)
+	game.State = Player2Move
+
+	state, err := controller.MakeMove(NewMove(4, 3, 1, 3))
+	if err != nil {
+		t.Error("It should have been possible to make a move but got", err)
+	}
+	if state != Player1NeutrinoMove {
+		t.Error("Expected the neutrino to be able to move across the edge,", Player1NeutrinoMove, "got", state)
+	}
+}
+
+func TestTorusTrappedNeutrinoOnEdge(t *testing.T) {
+	game, controller := SetupTorusGame(false)
+
+	game.SetLocation(0, 1, Player1)
+	game.SetLocation(1, 1, Player1)
+	game.SetLocation(1, 2, Player1)
+	game.SetLocation(4, 1, Player1)
+	game.SetLocation(0, 3, Player2)
+	game.SetLocation(4, 2, Player2)
+	game.SetLocation(4, 3, Player2)
+	game.SetLocation(3, 3, Player2)
+	game.SetLocation(0, 2, Neutrino)
+	game.State = Player2Move
+
+	state, err := controller.MakeMove(NewMove(3, 3, 1, 3))
+	if err != nil {
+		t.Error("It should have been possible to make a move but got", err)
+	}
+	if state != Player2Win || game.WinReason != NeutrinoTrapped {
+		t.Error("Expected", Player2Win, "by", NeutrinoTrapped, "got", state, "by", game.WinReason)
+	}
+}
+
+func TestTorusLegalMovesMatchesMakeMove(t *testing.T) {
+	for _, wrapRows := range []bool{false, true} {
+		game := NewStandardGame()
+		controller := NewController(TorusRules(wrapRows))
+		controller.PlayGame(game)
+
+		for _, move := range realGameMoves[:4] {
+			checkLegalMovesAgainstMakeMove(game, controller, t)
+			controller.MakeMove(move)
+		}
+		checkLegalMovesAgainstMakeMove(game, controller, t)
+	}
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMakeTurn(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+
+	state, err := controller.MakeTurn(NewMove(2, 2, 2, 3), NewMove(0, 0, 0, 3))
+	if err != nil {
+		t.Error("Expected no error got", err)
+	}
+	if state != Player2NeutrinoMove {
+		t.Error("Expected", Player2NeutrinoMove, "got", state)
+	}
+	if controller.Ply() != 2 {
+		t.Error("Expected both moves to be played got", controller.History())
+	}
+}
+
+func TestMakeTurnRollsBackNeutrinoMove(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+
+	state, err := controller.MakeTurn(NewMove(2, 2, 2, 3), NewMove(0, 0, 0, 2))
+	if !errors.Is(err, ErrDidNotSlideToObstacle) {
+		t.Error("Expected", ErrDidNotSlideToObstacle, "got", err)
+	}
+	if state != Player1NeutrinoMove {
+		t.Error("Expected", Player1NeutrinoMove, "got", state)
+	}
+	if isEqual, difference := Compare(game, NewStandardGame()); !isEqual {
+		t.Error("Expected the neutrino move to be taken back got", difference)
+	}
+	if controller.Ply() != 0 || controller.Redo() != ErrNothingToRedo {
+		t.Error("Expected the neutrino move to be forgotten got", controller.History())
+	}
+}
+
+func TestMakeTurnWithIllegalNeutrinoMove(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+
+	_, err := controller.MakeTurn(NewMove(2, 2, 4, 3), NewMove(0, 0, 0, 3))
+	if !errors.Is(err, ErrNotStraightLine) {
+		t.Error("Expected", ErrNotStraightLine, "got", err)
+	}
+	if isEqual, difference := Compare(game, NewStandardGame()); !isEqual {
+		t.Error("Expected nothing to be moved got", difference)
+	}
+
+	game.State = Player1Move
+	if _, err := controller.MakeTurn(NewMove(2, 2, 2, 3), NewMove(0, 0, 0, 3)); !errors.Is(err, ErrWrongTurn) {
+		t.Error("Expected", ErrWrongTurn, "when it is time to move a piece got", err)
+	}
+}
+
+func TestMakeTurnEndingAfterNeutrinoMove(t *testing.T) {
+	game, controller := SetupCenteredGame()
+	game.SetLocation(0, 0, Player1)
+
+	state, err := controller.MakeTurn(NewMove(2, 2, 2, 4), NewMove(0, 0, 0, 3))
+	if err != nil {
+		t.Error("Expected no error got", err)
+	}
+	if state != Player1Win || controller.Ply() != 1 {
+		t.Error("Expected", Player1Win, "after only the neutrino move got", state, controller.History())
+	}
+}
+
+func TestLegalTurns(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+	makeMoveAndCheckError(2, 2, 2, 3, controller, t)
+	controller.Undo()
+
+	turns := controller.LegalTurns()
+	if len(turns) == 0 {
+		t.Fatal("Expected legal turns from the standard game")
+	}
+	for _, turn := range turns {
+		copyOfGame := *game
+		copyController := NewController(StandardRules())
+		copyController.PlayGame(&copyOfGame)
+		if _, err := copyController.MakeTurn(turn.NeutrinoMove, turn.PieceMove); err != nil {
+			t.Error("Expected", turn, "to be a legal turn got", err)
+		}
+	}
+
+	if isEqual, difference := Compare(game, NewStandardGame()); !isEqual {
+		t.Error("Expected the game to be unchanged got", difference)
+	}
+	if err := controller.Redo(); err != nil || controller.Ply() != 1 {
+		t.Error("Expected the undone move to still be redone got", err)
+	}
+	turns = controller.LegalTurns()
+	checkLegalMoves(controller.LegalMoves(), pieceMovesOf(turns, t), t)
+}
+
+//The piece moves of turns without a neutrino move
+func pieceMovesOf(turns []Turn, t *testing.T) []Move {
+	var moves []Move
+	for _, turn := range turns {
+		if turn.NeutrinoMove != (Move{}) {
+			t.Error("Expected no neutrino move in", turn)
+		}
+		moves = append(moves, turn.PieceMove)
+	}
+	return moves
+}
+
+func TestTurnsWithoutNeutrinoMove(t *testing.T) {
+	handicapGame, _ := NewHandicapGame(HandicapPlayer2Starts)
+	games := []*Game{NewStandardGame(), handicapGame}
+	rules := []Rules{OfficialRules(), HandicapRules(HandicapPlayer2Starts)}
+	expectedStates := []State{Player2NeutrinoMove, Player1NeutrinoMove}
+
+	for i, game := range games {
+		controller := NewController(rules[i])
+		controller.PlayGame(game)
+		turns := controller.LegalTurns()
+		if len(turns) == 0 {
+			t.Fatal("Expected turns moving a piece in", game.State)
+		}
+		checkLegalMoves(controller.LegalMoves(), pieceMovesOf(turns, t), t)
+
+		if _, err := controller.MakeTurn(NewMove(2, 2, 2, 3), turns[0].PieceMove); !errors.Is(err, ErrWrongTurn) {
+			t.Error("Expected", ErrWrongTurn, "for a neutrino move when it is time to move a piece got", err)
+		}
+		state, err := controller.MakeTurn(turns[0].NeutrinoMove, turns[0].PieceMove)
+		if err != nil || state != expectedStates[i] {
+			t.Error("Expected", expectedStates[i], "after", turns[0], "got", state, err)
+		}
+		if _, err := controller.MakeTurn(Move{}, NewMove(0, 0, 0, 3)); !errors.Is(err, ErrWrongTurn) {
+			t.Error("Expected", ErrWrongTurn, "for a turn without a neutrino move got", err)
+		}
+	}
+}
+
+func TestLegalTurnsEndingAfterNeutrinoMove(t *testing.T) {
+	_, controller := SetupCenteredGame()
+
+	//Player 1 has no pieces so every neutrino move ends the game
+	turns := controller.LegalTurns()
+	if len(turns) != 8 {
+		t.Error("Expected 8 turns got", turns)
+	}
+	for _, turn := range turns {
+		if turn.PieceMove != (Move{}) {
+			t.Error("Expected no piece move in", turn)
+		}
+	}
+}
+
+func TestLegalTurnsToStringAndBack(t *testing.T) {
+	_, centered := SetupCenteredGame()
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+	official := NewController(OfficialRules())
+	official.PlayGame(NewStandardGame())
+
+	for _, turns := range [][]Turn{centered.LegalTurns(), controller.LegalTurns(), official.LegalTurns()} {
+		for _, turn := range turns {
+			parsed, err := ParseTurn(turn.String())
+			if err != nil || parsed != turn {
+				t.Error("Expected to parse", turn.String(), "back to", turn, "got", parsed, err)
+			}
+		}
+	}
+}
@@ -144,3 +144,73 @@ func TestMoveOwnNeutrinoToP2HomeRowWins(t *testing.T) {
 		t.Error("Expected", Player1Win, " but state was", state)
 	}
 }
+
+func TestHomeRowReachedIsTheWinReason(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(2, 1, Neutrino)
+	game.SetLocation(0, 2, Player1)
+	game.State = Player1NeutrinoMove
+	controller.MakeMove(NewMove(2, 1, 2, 4))
+	if game.State != Player1Win || game.WinReason != HomeRowReached {
+		t.Error("Expected", Player1Win, "by", HomeRowReached, "got", game.State, "by", game.WinReason)
+	}
+}
+
+func TestTrappedNeutrinoOnEdgeIsTheWinReason(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(0, 2, Neutrino)
+	game.SetLocation(0, 1, Player1)
+	game.SetLocation(1, 1, Player1)
+	game.SetLocation(1, 2, Player1)
+	game.SetLocation(0, 3, Player2)
+	game.SetLocation(4, 3, Player2)
+	game.State = Player2Move
+
+	state, err := controller.MakeMove(NewMove(4, 3, 1, 3))
+	if err != nil {
+		t.Error("It should have been possible to make a move but got", err)
+	}
+	if state != Player2Win || game.WinReason != NeutrinoTrapped {
+		t.Error("Expected", Player2Win, "by", NeutrinoTrapped, "got", state, "by", game.WinReason)
+	}
+}
+
+func TestImmobilisedPlayerLooses(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player2)
+	game.SetLocation(0, 1, Player2)
+	game.SetLocation(1, 1, Player2)
+	game.SetLocation(3, 2, Neutrino)
+	game.State = Player1NeutrinoMove
+
+	state, err := controller.MakeMove(NewMove(3, 2, 4, 2))
+	if err != nil {
+		t.Error("It should have been possible to make a move but got", err)
+	}
+	if state != Player2Win || game.WinReason != OpponentImmobilised {
+		t.Error("Expected", Player2Win, "by", OpponentImmobilised, "got", state, "by", game.WinReason)
+	}
+	if err := game.Validate(); err != nil {
+		t.Error("Expected the won game to be valid, got", err)
+	}
+}
+
+func TestUndoClearsWinReason(t *testing.T) {
+	game, controller := SetupEmptyGame()
+
+	game.SetLocation(2, 1, Neutrino)
+	game.State = Player1NeutrinoMove
+	controller.MakeMove(NewMove(2, 1, 2, 4))
+	controller.Undo()
+	if game.WinReason != NoWinner {
+		t.Error("Expected", NoWinner, "after undo got", game.WinReason)
+	}
+	controller.Redo()
+	if game.WinReason != HomeRowReached {
+		t.Error("Expected", HomeRowReached, "after redo got", game.WinReason)
+	}
+}
@@ -4,17 +4,44 @@ import "fmt"
 
 //// Game type ////
 
+const (
+	standardBoardSize = 5
+	largeBoardSize    = 7
+	minBoardSize      = 3
+	maxSquares        = 64
+)
+
+/**
+ * A board of width times height squares. The zero
+ * value is an empty board of the standard size 5x5.
+ */
 type Game struct {
-	game  [25]Entry
-	State State
+	game [maxSquares]Entry
+	//The bitboards of the entries and the squares
+	//that are not empty, see bitboard.go
+	pieces   [entryKinds]uint64
+	occupied uint64
+	//The Zobrist hash of the board, see Hash
+	hash          uint64
+	width, height byte
+	State         State
+	//Why the game was won, NoWinner while it is being played
+	WinReason WinReason
+	//Whether the board is the starting position of a
+	//variant, set by its constructor and cleared by any
+	//change to the board, see Controller.PlayGame
+	opening bool
 }
 
 func Compare(a, b *Game) (isEqual bool, difference string) {
 	if a == b {
 		return true, ""
 	}
-	for x := byte(1); x < 5; x++ {
-		for y := byte(1); y < 5; y++ {
+	if a.Width() != b.Width() || a.Height() != b.Height() {
+		return false, fmt.Sprintf("Different sizes %dx%d and %dx%d", a.Width(), a.Height(), b.Width(), b.Height())
+	}
+	for x := byte(0); x < a.Width(); x++ {
+		for y := byte(0); y < a.Height(); y++ {
 			entryA, _ := a.GetLocation(x, y)
 			entryB, _ := b.GetLocation(x, y)
 			if entryA != entryB {
@@ -29,42 +56,173 @@ func Compare(a, b *Game) (isEqual bool, difference string) {
 }
 
 func NewEmptyGame() *Game {
-	game := &Game{}
-	for x := byte(0); x < 5; x++ {
-		for y := byte(0); y < 5; y++ {
+	game, _ := NewEmptyGameOfSize(standardBoardSize, standardBoardSize)
+	return game
+}
+
+/**
+ * An empty board of the given size. A board must be
+ * at least 3x3 and have at most 64 squares.
+ */
+func NewEmptyGameOfSize(width, height byte) (*Game, error) {
+	if width < minBoardSize || height < minBoardSize || int(width)*int(height) > maxSquares {
+		return nil, fmt.Errorf("Board must be at least %dx%d and have at most %d squares. Was %dx%d", minBoardSize, minBoardSize, maxSquares, width, height)
+	}
+	game := &Game{width: width, height: height}
+	for x := byte(0); x < width; x++ {
+		for y := byte(0); y < height; y++ {
 			game.SetLocation(x, y, EmptySquare)
 		}
 	}
 	game.State = Player1NeutrinoMove
-	return game
+	return game, nil
 }
 
 func NewStandardGame() *Game {
-	game := NewEmptyGame()
-	for i := byte(0); i < 5; i++ {
+	game, _ := NewStandardGameOfSize(standardBoardSize, standardBoardSize)
+	return game
+}
+
+/**
+ * The 7x7 variant with seven pieces per player
+ */
+func NewLargeGame() *Game {
+	game, _ := NewStandardGameOfSize(largeBoardSize, largeBoardSize)
+	return game
+}
+
+/**
+ * A game where each player fills their home row
+ * and the neutrino is placed in the middle
+ */
+func NewStandardGameOfSize(width, height byte) (*Game, error) {
+	game, err := NewEmptyGameOfSize(width, height)
+	if err != nil {
+		return nil, err
+	}
+	for i := byte(0); i < width; i++ {
 		game.SetLocation(i, 0, Player1)
-		game.SetLocation(i, 4, Player2)
+		game.SetLocation(i, height-1, Player2)
 	}
-	game.SetLocation(2, 2, Neutrino)
+	game.SetLocation(width/2, height/2, Neutrino)
 	game.State = Player1NeutrinoMove
+	game.opening = true
+	return game, nil
+}
+
+/**
+ * A standard game with the given number of neutrinos
+ * spread out evenly along the middle row
+ */
+func NewMultiNeutrinoGame(neutrinos byte) (*Game, error) {
+	game := NewStandardGame()
+	width := game.Width()
+	if neutrinos == 0 || neutrinos > width {
+		return nil, fmt.Errorf("Number of neutrinos must be between 1 and %d. Was %d", width, neutrinos)
+	}
+	game.SetLocation(width/2, game.Height()/2, EmptySquare)
+	for i := byte(0); i < neutrinos; i++ {
+		game.SetLocation((2*i+1)*width/(2*neutrinos), game.Height()/2, Neutrino)
+	}
+	game.opening = true
+	return game, nil
+}
+
+/**
+ * The four player variant on a 7x7 board, see
+ * NewFourPlayerGameOfSize
+ */
+func NewFourPlayerGame() *Game {
+	game, _ := NewFourPlayerGameOfSize(largeBoardSize)
 	return game
 }
 
-const (
-	widthOfBoard = 5
-)
+/**
+ * A square board where player 1 starts on the north
+ * edge, player 2 on the south, player 3 on the east and
+ * player 4 on the west. The corners are left empty so
+ * every player has two pieces less than the board is wide.
+ */
+func NewFourPlayerGameOfSize(size byte) (*Game, error) {
+	if size < largeBoardSize {
+		return nil, fmt.Errorf("Four player board must be at least %dx%d. Was %dx%d", largeBoardSize, largeBoardSize, size, size)
+	}
+	game, err := NewEmptyGameOfSize(size, size)
+	if err != nil {
+		return nil, err
+	}
+	for i := byte(1); i < size-1; i++ {
+		game.SetLocation(i, 0, Player1)
+		game.SetLocation(i, size-1, Player2)
+		game.SetLocation(size-1, i, Player3)
+		game.SetLocation(0, i, Player4)
+	}
+	game.SetLocation(size/2, size/2, Neutrino)
+	game.State = Player1NeutrinoMove
+	game.opening = true
+	return game, nil
+}
+
+func (self *Game) Width() byte {
+	if self.width == 0 {
+		return standardBoardSize
+	}
+	return self.width
+}
+
+func (self *Game) Height() byte {
+	if self.height == 0 {
+		return standardBoardSize
+	}
+	return self.height
+}
 
 func (self *Game) SetLocation(x, y byte, entry Entry) error {
-	if x > 4 || y > 4 {
-		return fmt.Errorf("Coordinates must be between (0,0) and (4,4) both inclusive. Was (%d, %d)", x, y)
+	if x >= self.Width() || y >= self.Height() {
+		return fmt.Errorf("Coordinates must be between (0,0) and (%d,%d) both inclusive. Was (%d, %d)", self.Width()-1, self.Height()-1, x, y)
+	}
+	self.opening = false
+	square := x + self.Width()*y
+	bit := uint64(1) << square
+	old := self.game[square]
+	if old < entryKinds {
+		self.pieces[old] &^= bit
+	}
+	self.hash ^= zobristKey(old, square) ^ zobristKey(entry, square)
+	self.game[square] = entry
+	if entry != EmptySquare && entry < entryKinds {
+		self.pieces[entry] |= bit
+	}
+	if entry == EmptySquare {
+		self.occupied &^= bit
+	} else {
+		self.occupied |= bit
 	}
-	self.game[x+widthOfBoard*y] = entry
 	return nil
 }
 
 func (self *Game) GetLocation(x, y byte) (Entry, error) {
-	if x > 4 || y > 4 {
-		return 9, fmt.Errorf("Coordinates must be between (0,0) and (4,4) both inclusive. Was (%d, %d)", x, y)
+	if x >= self.Width() || y >= self.Height() {
+		return 9, fmt.Errorf("Coordinates must be between (0,0) and (%d,%d) both inclusive. Was (%d, %d)", self.Width()-1, self.Height()-1, x, y)
 	}
-	return self.game[x+widthOfBoard*y], nil
+	return self.game[x+self.Width()*y], nil
+}
+
+/**
+ * Whether player 1 is about to make the first move of
+ * the game, either in the starting position made by the
+ * constructor of a variant or on a board set up as the
+ * standard opening, e.g. by UnmarshalText
+ */
+func (self *Game) isOpening() bool {
+	if self.State != Player1NeutrinoMove {
+		return false
+	}
+	return self.opening || self.hasStandardOpeningBoard()
+}
+
+//Whether every piece and neutrino is placed as in NewStandardGameOfSize
+func (self *Game) hasStandardOpeningBoard() bool {
+	opening, err := NewStandardGameOfSize(self.Width(), self.Height())
+	return err == nil && self.pieces == opening.pieces
 }
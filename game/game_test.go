@@ -170,3 +170,107 @@ func TestCompareDifferentInOnePiece(t *testing.T) {
 		t.Error("Expected an explanation, got nothing")
 	}
 }
+
+func TestNewLargeGameLayout(t *testing.T) {
+	game := NewLargeGame()
+
+	if game.Width() != 7 || game.Height() != 7 {
+		t.Fatal("Expected a 7x7 game got", game.Width(), "x", game.Height())
+	}
+	for x := byte(0); x < 7; x++ {
+		for y := byte(0); y < 7; y++ {
+			expectedPiece := EmptySquare
+			if y == 0 {
+				expectedPiece = Player1
+			} else if y == 6 {
+				expectedPiece = Player2
+			} else if x == 3 && y == 3 {
+				expectedPiece = Neutrino
+			}
+			actualEntry, _ := game.GetLocation(x, y)
+			if actualEntry != expectedPiece {
+				t.Errorf("Expected %q at (%d, %d) got %q", expectedPiece, x, y, actualEntry)
+			}
+		}
+	}
+}
+
+func TestGetLocationOutsideLargeBoardGivesError(t *testing.T) {
+	game := NewLargeGame()
+	for i := byte(0); i < 10; i++ {
+		for j := byte(0); j < 10; j++ {
+			_, err := game.GetLocation(i, j)
+			if (i > 6 || j > 6) && err == nil {
+				t.Errorf("Expected to get an error when asking for location at (%d,  %d) but got no error", i, j)
+			} else if i <= 6 && j <= 6 && err != nil {
+				t.Errorf("Expected not to get an error when asking for location at (%d,  %d) but got %q", i, j, err)
+			}
+		}
+	}
+}
+
+func TestNewEmptyGameOfInvalidSize(t *testing.T) {
+	invalidSizes := [][2]byte{{2, 5}, {5, 2}, {9, 8}, {0, 0}, {255, 255}}
+	for _, size := range invalidSizes {
+		if _, err := NewEmptyGameOfSize(size[0], size[1]); err == nil {
+			t.Error("Expected an error when creating a board of size", size)
+		}
+	}
+	if _, err := NewEmptyGameOfSize(8, 8); err != nil {
+		t.Error("Expected to be able to create a board of size 8x8, got", err)
+	}
+}
+
+func TestCompareDifferentSizes(t *testing.T) {
+	result, explanation := Compare(NewStandardGame(), NewLargeGame())
+	if result != false {
+		t.Error("Expected the two games to be different but got ", result)
+	}
+	if explanation == "" {
+		t.Error("Expected an explanation, got nothing")
+	}
+}
+
+func TestCompareDifferentOnHomeRow(t *testing.T) {
+	game1 := NewStandardGame()
+	game2 := NewStandardGame()
+	game1.SetLocation(0, 0, EmptySquare)
+	if result, _ := Compare(game1, game2); result != false {
+		t.Error("Expected the two games to be different but got ", result)
+	}
+}
+
+func TestBitboardsFollowSetLocation(t *testing.T) {
+	game := NewStandardGame()
+	game.SetLocation(0, 0, EmptySquare)
+	game.SetLocation(0, 2, Player1)
+	game.SetLocation(2, 2, Player2)
+	game.SetLocation(4, 2, 7)
+
+	for y := byte(0); y < 5; y++ {
+		for x := byte(0); x < 5; x++ {
+			entry, _ := game.GetLocation(x, y)
+			bit := uint64(1) << (x + 5*y)
+			if (game.occupied&bit != 0) != (entry != EmptySquare) {
+				t.Error("Expected the occupied bit at", x, y, "to match", entry)
+			}
+			for kind := Player1; kind < entryKinds; kind++ {
+				if (game.pieces[kind]&bit != 0) != (entry == kind) {
+					t.Error("Expected the bit of", kind, "at", x, y, "to match", entry)
+				}
+			}
+		}
+	}
+}
+
+func TestUInt64IntoLargerGameClearsBitboards(t *testing.T) {
+	game := NewLargeGame()
+	UInt64IntoGame(GameToUInt64(NewStandardGame()), game)
+
+	if isEqual, difference := Compare(game, NewStandardGame()); !isEqual {
+		t.Error("Expected the standard game got", difference)
+	}
+	if neutrinos := (&Controller{game: game}).locateNeutrinos(); len(neutrinos) != 1 {
+		t.Error("Expected only the neutrino of the standard game got", neutrinos)
+	}
+}
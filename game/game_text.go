@@ -0,0 +1,171 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/**
+ * A compact one line text format for a game, similar
+ * to FEN in chess. The rows are written from y = 0
+ * separated by '/', where 'x' is a player 1 piece, 'o'
+ * a player 2 piece, 'n' the neutrino and a number is
+ * that many empty squares. In the four player variant
+ * 'y' is a player 3 piece and 'z' a player 4 piece.
+ * After a space follows the player and the phase, 'n'
+ * for moving the neutrino, 'p' for moving a piece and
+ * 'w' for having won.
+ * The number of rows and squares per row gives the size
+ * of the board. The standard game is
+ *
+ *   xxxxx/5/2n2/5/ooooo 1n
+ */
+
+var entryToText = map[Entry]byte{
+	Player1:  'x',
+	Player2:  'o',
+	Neutrino: 'n',
+	Player3:  'y',
+	Player4:  'z',
+}
+
+var stateToText = map[State]string{
+	Player1NeutrinoMove: "1n",
+	Player1Move:         "1p",
+	Player2NeutrinoMove: "2n",
+	Player2Move:         "2p",
+	Player1Win:          "1w",
+	Player2Win:          "2w",
+	Player3NeutrinoMove: "3n",
+	Player3Move:         "3p",
+	Player4NeutrinoMove: "4n",
+	Player4Move:         "4p",
+	Player3Win:          "3w",
+	Player4Win:          "4w",
+}
+
+func (self *Game) MarshalText() ([]byte, error) {
+	stateText, ok := stateToText[self.State]
+	if !ok {
+		return nil, fmt.Errorf("Cannot write a game in the unknown state %d", self.State)
+	}
+
+	var buffer bytes.Buffer
+	for y := byte(0); y < self.Height(); y++ {
+		if y > 0 {
+			buffer.WriteByte('/')
+		}
+		empty := 0
+		for x := byte(0); x < self.Width(); x++ {
+			entry, _ := self.GetLocation(x, y)
+			if entry == EmptySquare {
+				empty++
+				continue
+			}
+			text, ok := entryToText[entry]
+			if !ok {
+				return nil, fmt.Errorf("Cannot write the unknown entry %d at (%d, %d)", entry, x, y)
+			}
+			if empty > 0 {
+				buffer.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			buffer.WriteByte(text)
+		}
+		if empty > 0 {
+			buffer.WriteString(strconv.Itoa(empty))
+		}
+	}
+	buffer.WriteByte(' ')
+	buffer.WriteString(stateText)
+	return buffer.Bytes(), nil
+}
+
+/**
+ * Reads a game written by MarshalText. The game is
+ * left untouched if the text is not a valid game.
+ */
+func (self *Game) UnmarshalText(text []byte) error {
+	fields := strings.Fields(string(text))
+	if len(fields) != 2 {
+		return fmt.Errorf("Game must be the rows followed by a space and the state. Was %q", text)
+	}
+
+	rows := strings.Split(fields[0], "/")
+	entries := make([][]Entry, len(rows))
+	for y, row := range rows {
+		rowEntries, err := unmarshalRow(y, row)
+		if err != nil {
+			return err
+		}
+		if len(rowEntries) != len(entries[0]) && y > 0 {
+			return fmt.Errorf("Row %d must have %d squares like row 1 but had %d. Was %q", y+1, len(entries[0]), len(rowEntries), row)
+		}
+		entries[y] = rowEntries
+	}
+	if len(entries[0]) > maxSquares || len(rows) > maxSquares {
+		return fmt.Errorf("Game cannot have more than %d squares. Was %q", maxSquares, fields[0])
+	}
+
+	game, err := NewEmptyGameOfSize(byte(len(entries[0])), byte(len(rows)))
+	if err != nil {
+		return err
+	}
+	for y, rowEntries := range entries {
+		for x, entry := range rowEntries {
+			game.SetLocation(byte(x), byte(y), entry)
+		}
+	}
+
+	stateFound := false
+	for state, stateText := range stateToText {
+		if stateText == fields[1] {
+			game.State = state
+			stateFound = true
+		}
+	}
+	if !stateFound {
+		return fmt.Errorf("Unknown state %q, must be a player followed by 'n', 'p' or 'w'", fields[1])
+	}
+
+	*self = *game
+	return nil
+}
+
+func unmarshalRow(y int, row string) ([]Entry, error) {
+	var entries []Entry
+	for i := 0; i < len(row); i++ {
+		if row[i] >= '0' && row[i] <= '9' {
+			start := i
+			for i+1 < len(row) && row[i+1] >= '0' && row[i+1] <= '9' {
+				i++
+			}
+			empty, err := strconv.Atoi(row[start : i+1])
+			if row[start] == '0' || err != nil || empty > maxSquares {
+				return nil, fmt.Errorf("Number of empty squares in row %d must be between 1 and %d. Was %q", y+1, maxSquares, row)
+			}
+			for j := 0; j < empty; j++ {
+				entries = append(entries, EmptySquare)
+			}
+			continue
+		}
+
+		entry, ok := textToEntry(row[i])
+		if !ok {
+			return nil, fmt.Errorf("Unknown piece %q in row %d, must be 'x', 'o', 'y', 'z', 'n' or a number", row[i], y+1)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func textToEntry(text byte) (Entry, bool) {
+	for entry, entryText := range entryToText {
+		if entryText == text {
+			return entry, true
+		}
+	}
+	return EmptySquare, false
+}
@@ -0,0 +1,109 @@
+package game
+
+import "testing"
+
+func TestMarshalStandardGame(t *testing.T) {
+	text, err := NewStandardGame().MarshalText()
+	if err != nil {
+		t.Error("Expected to be able to marshal the game, got", err)
+	}
+	if string(text) != "xxxxx/5/2n2/5/ooooo 1n" {
+		t.Error("Expected xxxxx/5/2n2/5/ooooo 1n got", string(text))
+	}
+}
+
+func TestMarshalSquaredGame(t *testing.T) {
+	game, _ := SetupSquaredGame()
+	game.SetLocation(4, 4, Player2)
+	game.State = Player2Win
+
+	text, _ := game.MarshalText()
+	if string(text) != "5/1n1n1/5/1n1n1/4o 2w" {
+		t.Error("Expected 5/1n1n1/5/1n1n1/4o 2w got", string(text))
+	}
+}
+
+func TestUnmarshalStandardGame(t *testing.T) {
+	game := &Game{}
+	if err := game.UnmarshalText([]byte("xxxxx/5/2n2/5/ooooo 1n")); err != nil {
+		t.Error("Expected to be able to unmarshal the game, got", err)
+	}
+	if result, message := Compare(NewStandardGame(), game); !result {
+		t.Error("Expected the standard game: ", message)
+	}
+}
+
+func TestMarshalRealGameRoundTrip(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	for _, move := range realGameMoves {
+		makeMoveAndCheckError(move.FromX, move.FromY, move.ToX, move.ToY, controller, t)
+
+		text, err := game.MarshalText()
+		if err != nil {
+			t.Error("Expected to be able to marshal the game, got", err)
+		}
+		unmarshalled := &Game{}
+		if err := unmarshalled.UnmarshalText(text); err != nil {
+			t.Error("Expected to be able to unmarshal", string(text), "got", err)
+		}
+		if result, message := Compare(game, unmarshalled); !result {
+			t.Error("Unmarshalled game does not match", string(text), ": ", message)
+		}
+	}
+}
+
+func TestUnmarshalInvalidText(t *testing.T) {
+	invalidTexts := []string{
+		"",
+		"xxxxx/5/2n2/5/ooooo",
+		"xxxxx/5/2n2/5/ooooo 1n extra",
+		"xxxxx/2n2/ooooo 1n x",
+		"xxxxx/ooooo 1n",
+		"xx/1n/oo 1n",
+		"8/8/8/8/3n4/8/8/8/8 1n",
+		"xxxx/5/2n2/5/ooooo 1n",
+		"xxxxxx/5/2n2/5/ooooo 1n",
+		"xxxxx/6/2n2/5/ooooo 1n",
+		"xxxxx/05/2n2/5/ooooo 1n",
+		"xxxxx/5/2q2/5/ooooo 1n",
+		"xxxxx/5/2n2/5/ooooo 5n",
+		"xxxxx/5/2n2/5/ooooo 1"}
+
+	for _, text := range invalidTexts {
+		game := NewStandardGame()
+		if err := game.UnmarshalText([]byte(text)); err == nil {
+			t.Error("Expected an error when unmarshalling", text)
+		}
+		if result, _ := Compare(NewStandardGame(), game); !result {
+			t.Error("Expected the game to be left untouched when unmarshalling", text)
+		}
+	}
+}
+
+func TestMarshalLargeGameRoundTrip(t *testing.T) {
+	text, _ := NewLargeGame().MarshalText()
+	if string(text) != "xxxxxxx/7/7/3n3/7/7/ooooooo 1n" {
+		t.Error("Expected xxxxxxx/7/7/3n3/7/7/ooooooo 1n got", string(text))
+	}
+
+	game := &Game{}
+	if err := game.UnmarshalText(text); err != nil {
+		t.Error("Expected to be able to unmarshal", string(text), "got", err)
+	}
+	if result, message := Compare(NewLargeGame(), game); !result {
+		t.Error("Expected the large game: ", message)
+	}
+}
+
+func TestUnmarshalRectangularGame(t *testing.T) {
+	game := &Game{}
+	if err := game.UnmarshalText([]byte("xxxxxx/6/3n2/6/oooooo 2p")); err != nil {
+		t.Error("Expected to be able to unmarshal a 6x5 game, got", err)
+	}
+	if game.Width() != 6 || game.Height() != 5 {
+		t.Error("Expected a 6x5 game got", game.Width(), "x", game.Height())
+	}
+}
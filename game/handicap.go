@@ -0,0 +1,87 @@
+package game
+
+import "fmt"
+
+/**
+ * Handicaps for games between uneven players. Player 1
+ * is the stronger player and player 2 the weaker player
+ * that is given the advantage.
+ */
+type Handicap byte
+
+const (
+	NoHandicap Handicap = iota
+	//Player 1 plays without the middle piece of their home row
+	HandicapOnePiece
+	//Player 1 plays without the pieces next to the corners
+	HandicapTwoPieces
+	//Player 2 moves the neutrino once before player 1 starts
+	HandicapExtraNeutrinoMove
+	//Player 2 starts by moving a piece
+	HandicapPlayer2Starts
+)
+
+var handicapNames = map[Handicap]string{
+	NoHandicap:                "None",
+	HandicapOnePiece:          "OnePiece",
+	HandicapTwoPieces:         "TwoPieces",
+	HandicapExtraNeutrinoMove: "ExtraNeutrinoMove",
+	HandicapPlayer2Starts:     "Player2Starts",
+}
+
+func (self Handicap) String() string {
+	if name, ok := handicapNames[self]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown handicap %d", byte(self))
+}
+
+func ParseHandicap(text string) (Handicap, error) {
+	for handicap, name := range handicapNames {
+		if name == text {
+			return handicap, nil
+		}
+	}
+	return NoHandicap, fmt.Errorf("Unknown handicap %q", text)
+}
+
+/**
+ * The standard game with the handicap applied, play
+ * it with HandicapRules for the controller to honour it
+ */
+func NewHandicapGame(handicap Handicap) (*Game, error) {
+	game := NewStandardGame()
+	width := game.Width()
+	switch handicap {
+	case NoHandicap:
+	case HandicapOnePiece:
+		game.SetLocation(width/2, 0, EmptySquare)
+	case HandicapTwoPieces:
+		game.SetLocation(1, 0, EmptySquare)
+		game.SetLocation(width-2, 0, EmptySquare)
+	case HandicapExtraNeutrinoMove:
+		game.State = Player2NeutrinoMove
+	case HandicapPlayer2Starts:
+		game.State = Player2Move
+	default:
+		return nil, fmt.Errorf("Unknown handicap %d", handicap)
+	}
+	game.opening = true
+	return game, nil
+}
+
+func HandicapRules(handicap Handicap) Rules {
+	return Rules{Handicap: handicap}
+}
+
+//The number of pieces player 1 plays without
+func (self Handicap) removedPieces() byte {
+	switch self {
+	case HandicapOnePiece:
+		return 1
+	case HandicapTwoPieces:
+		return 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewHandicapGame(t *testing.T) {
+	handicaps := map[Handicap]string{
+		NoHandicap:                "xxxxx/5/2n2/5/ooooo 1n",
+		HandicapOnePiece:          "xx1xx/5/2n2/5/ooooo 1n",
+		HandicapTwoPieces:         "x1x1x/5/2n2/5/ooooo 1n",
+		HandicapExtraNeutrinoMove: "xxxxx/5/2n2/5/ooooo 2n",
+		HandicapPlayer2Starts:     "xxxxx/5/2n2/5/ooooo 2p"}
+
+	for handicap, expected := range handicaps {
+		game, err := NewHandicapGame(handicap)
+		if err != nil {
+			t.Fatal("Expected no error for", handicap, "got", err)
+		}
+		if text, _ := game.MarshalText(); string(text) != expected {
+			t.Error("Expected", expected, "for", handicap, "got", string(text))
+		}
+		if err := game.ValidateFor(HandicapRules(handicap)); err != nil {
+			t.Error("Expected the game to be valid for", handicap, "got", err)
+		}
+	}
+
+	if _, err := NewHandicapGame(9); err == nil {
+		t.Error("Expected an error for an unknown handicap")
+	}
+}
+
+func TestExtraNeutrinoMove(t *testing.T) {
+	game, _ := NewHandicapGame(HandicapExtraNeutrinoMove)
+	controller := NewController(HandicapRules(HandicapExtraNeutrinoMove))
+	controller.PlayGame(game)
+
+	moves := []Move{NewMove(2, 2, 2, 3), NewMove(2, 3, 2, 1), NewMove(0, 0, 0, 3), NewMove(2, 1, 2, 3)}
+	expectedStates := []State{Player1NeutrinoMove, Player1Move, Player2NeutrinoMove, Player2Move}
+	for i, move := range moves {
+		state, err := controller.MakeMove(move)
+		if err != nil {
+			t.Fatal("Expected no error for", move, "got", err)
+		}
+		if state != expectedStates[i] {
+			t.Fatal("Expected", expectedStates[i], "after", move, "got", state)
+		}
+	}
+
+	for i := 0; i < len(moves); i++ {
+		controller.Undo()
+	}
+	if game.State != Player2NeutrinoMove {
+		t.Error("Expected", Player2NeutrinoMove, "after undoing every move got", game.State)
+	}
+	controller.Redo()
+	if game.State != Player1NeutrinoMove {
+		t.Error("Expected", Player1NeutrinoMove, "after redoing the extra neutrino move got", game.State)
+	}
+}
+
+func TestExtraNeutrinoMoveWithNewControllerEachMove(t *testing.T) {
+	game, _ := NewHandicapGame(HandicapExtraNeutrinoMove)
+
+	moves := []Move{NewMove(2, 2, 2, 3), NewMove(2, 3, 2, 1), NewMove(0, 0, 0, 3), NewMove(2, 1, 2, 3), NewMove(4, 4, 4, 1)}
+	expectedStates := []State{Player1NeutrinoMove, Player1Move, Player2NeutrinoMove, Player2Move, Player1NeutrinoMove}
+	for i, move := range moves {
+		controller := NewController(HandicapRules(HandicapExtraNeutrinoMove))
+		controller.PlayGame(game)
+		state, err := controller.MakeMove(move)
+		if err != nil {
+			t.Fatal("Expected no error for", move, "got", err)
+		}
+		if state != expectedStates[i] {
+			t.Fatal("Expected", expectedStates[i], "after", move, "got", state)
+		}
+	}
+}
+
+func TestExtraNeutrinoMoveOnlyInOpening(t *testing.T) {
+	//Every piece is back on its home row, but the
+	//neutrino shows that the game is not in the opening
+	game := &Game{}
+	game.UnmarshalText([]byte("xxxxx/5/1n3/5/ooooo 2n"))
+	controller := NewController(Rules{Handicap: HandicapExtraNeutrinoMove, AllowFullHomeRow: true})
+	controller.PlayGame(game)
+
+	state, err := controller.MakeMove(NewMove(1, 2, 1, 3))
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if state != Player2Move {
+		t.Error("Expected", Player2Move, "after the extra neutrino move was made got", state)
+	}
+}
+
+func TestExtraNeutrinoMoveAfterUndo(t *testing.T) {
+	game, _ := NewHandicapGame(HandicapExtraNeutrinoMove)
+	controller := NewController(HandicapRules(HandicapExtraNeutrinoMove))
+	controller.PlayGame(game)
+
+	makeMoveAndCheckError(2, 2, 2, 3, controller, t)
+	controller.Undo()
+	if state, err := controller.MakeMove(NewMove(2, 2, 2, 1)); err != nil || state != Player1NeutrinoMove {
+		t.Error("Expected", Player1NeutrinoMove, "after another extra neutrino move got", state, err)
+	}
+}
+
+func TestPlayer2Starts(t *testing.T) {
+	game, _ := NewHandicapGame(HandicapPlayer2Starts)
+	controller := NewController(HandicapRules(HandicapPlayer2Starts))
+	controller.PlayGame(game)
+
+	state, err := controller.MakeMove(NewMove(0, 4, 0, 1))
+	if err != nil {
+		t.Error("Expected no error got", err)
+	}
+	if state != Player1NeutrinoMove {
+		t.Error("Expected", Player1NeutrinoMove, "got", state)
+	}
+}
+
+func TestHomeRowRestrictionWithRemovedPiece(t *testing.T) {
+	restricted := map[Rules]bool{
+		StandardRules():                 false,
+		HandicapRules(HandicapOnePiece): true}
+
+	for rules, isRestricted := range restricted {
+		game, _ := NewHandicapGame(HandicapOnePiece)
+		controller := NewController(rules)
+		controller.PlayGame(game)
+		game.State = Player1Move
+		makeMoveAndCheckError(0, 0, 0, 3, controller, t)
+
+		game.State = Player1Move
+		_, err := controller.MakeMove(NewMove(0, 3, 0, 0))
+		if isRestricted && !errors.Is(err, ErrHomeRowRestriction) {
+			t.Error("Expected", ErrHomeRowRestriction, "got", err)
+		} else if !isRestricted && err != nil {
+			t.Error("Expected no error without the handicap rules got", err)
+		}
+	}
+}
+
+func TestRecordWithHandicap(t *testing.T) {
+	record := &Record{
+		Handicap: HandicapExtraNeutrinoMove,
+		Moves:    []Move{NewMove(2, 2, 2, 3), NewMove(2, 3, 2, 1)},
+	}
+
+	var buffer bytes.Buffer
+	WriteRecord(&buffer, record)
+	if !strings.Contains(buffer.String(), `[Handicap "ExtraNeutrinoMove"]`) {
+		t.Error("Expected the handicap to be written got", buffer.String())
+	}
+
+	readRecord, err := ReadRecord(&buffer)
+	if err != nil {
+		t.Fatal("Expected to be able to read the record, got", err)
+	}
+	if readRecord.Handicap != HandicapExtraNeutrinoMove {
+		t.Error("Expected", HandicapExtraNeutrinoMove, "got", readRecord.Handicap)
+	}
+	game, err := readRecord.Replay()
+	if err != nil {
+		t.Error("Expected to be able to replay the record, got", err)
+	}
+	if game.State != Player1Move {
+		t.Error("Expected", Player1Move, "got", game.State)
+	}
+
+	if _, err := ReadRecord(strings.NewReader(`[Handicap "Queen"]`)); err == nil {
+		t.Error("Expected an error for an unknown handicap")
+	}
+}
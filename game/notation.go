@@ -0,0 +1,118 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/**
+ * Moves are written with algebraic squares where the
+ * columns are named by letters starting with 'a' and
+ * the rows are numbered from 1, e.g. "c3-e5". Row 1 is
+ * y = 0, the home row of player 1.
+ *
+ * A turn is the neutrino move followed by the piece
+ * move separated by a space, e.g. "c3-d4 a1-a3". A
+ * turn ending the game with the neutrino move has no
+ * piece move and is written as the neutrino move only.
+ * A turn starting with the piece move, e.g. the first
+ * turn by the official rules, has no neutrino move and
+ * is written with "..." in its place, e.g. "... a1-a3".
+ */
+
+//// Move notation ////
+
+func (self Move) String() string {
+	return squareToString(self.FromX, self.FromY) + "-" + squareToString(self.ToX, self.ToY)
+}
+
+func ParseMove(s string) (Move, error) {
+	squares := strings.Split(strings.TrimSpace(s), "-")
+	if len(squares) != 2 {
+		return Move{}, fmt.Errorf("Move must be two squares separated by '-'. Was %q", s)
+	}
+	fromX, fromY, err := parseSquare(squares[0])
+	if err != nil {
+		return Move{}, err
+	}
+	toX, toY, err := parseSquare(squares[1])
+	if err != nil {
+		return Move{}, err
+	}
+	return NewMove(fromX, fromY, toX, toY), nil
+}
+
+func squareToString(x, y byte) string {
+	return string(rune('a'+x)) + strconv.Itoa(int(y)+1)
+}
+
+func parseSquare(s string) (byte, byte, error) {
+	s = strings.ToLower(s)
+	if len(s) < 2 || s[0] < 'a' || s[0] > 'z' {
+		return 0, 0, fmt.Errorf("Square must be a column from 'a' followed by a row from 1. Was %q", s)
+	}
+	row, err := strconv.ParseUint(s[1:], 10, 8)
+	if err != nil || row == 0 {
+		return 0, 0, fmt.Errorf("Square must be a column from 'a' followed by a row from 1. Was %q", s)
+	}
+	return s[0] - 'a', byte(row - 1), nil
+}
+
+//// Turn type ////
+
+/**
+ * A full turn for a player, first moving the
+ * neutrino and then one of the players own pieces
+ */
+type Turn struct {
+	NeutrinoMove Move
+	PieceMove    Move
+}
+
+func NewTurn(neutrinoMove, pieceMove Move) Turn {
+	return Turn{
+		NeutrinoMove: neutrinoMove,
+		PieceMove:    pieceMove,
+	}
+}
+
+const missingNeutrinoMove = "..."
+
+//A zero piece move is left out and a zero neutrino move written as "..."
+func (self Turn) String() string {
+	if self.NeutrinoMove == (Move{}) {
+		return missingNeutrinoMove + " " + self.PieceMove.String()
+	}
+	if self.PieceMove == (Move{}) {
+		return self.NeutrinoMove.String()
+	}
+	return self.NeutrinoMove.String() + " " + self.PieceMove.String()
+}
+
+//A turn of a single move has the zero piece move and "..." is the zero neutrino move
+func ParseTurn(s string) (Turn, error) {
+	moves := strings.Fields(s)
+	if len(moves) != 1 && len(moves) != 2 {
+		return Turn{}, fmt.Errorf("Turn must be a neutrino move optionally followed by a piece move separated by a space. Was %q", s)
+	}
+	if moves[0] == missingNeutrinoMove && len(moves) == 2 {
+		pieceMove, err := ParseMove(moves[1])
+		if err != nil {
+			return Turn{}, err
+		}
+		return NewTurn(Move{}, pieceMove), nil
+	}
+	neutrinoMove, err := ParseMove(moves[0])
+	if err != nil {
+		return Turn{}, err
+	}
+	if len(moves) == 1 {
+		return NewTurn(neutrinoMove, Move{}), nil
+	}
+	pieceMove, err := ParseMove(moves[1])
+	if err != nil {
+		return Turn{}, err
+	}
+	return NewTurn(neutrinoMove, pieceMove), nil
+}
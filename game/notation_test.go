@@ -0,0 +1,112 @@
+package game
+
+import "testing"
+
+func TestMoveToString(t *testing.T) {
+	checks := map[Move]string{
+		NewMove(2, 2, 4, 4): "c3-e5",
+		NewMove(0, 0, 0, 3): "a1-a4",
+		NewMove(4, 1, 1, 1): "e2-b2"}
+
+	for move, expected := range checks {
+		if move.String() != expected {
+			t.Error("Expected", expected, "got", move.String())
+		}
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	checks := map[string]Move{
+		"c3-e5":   NewMove(2, 2, 4, 4),
+		"a1-a4":   NewMove(0, 0, 0, 3),
+		"E2-B2":   NewMove(4, 1, 1, 1),
+		" b5-d3 ": NewMove(1, 4, 3, 2)}
+
+	for notation, expected := range checks {
+		move, err := ParseMove(notation)
+		if err != nil {
+			t.Error("Expected to be able to parse", notation, "got", err)
+		}
+		if move != expected {
+			t.Error("Expected", expected, "got", move)
+		}
+	}
+}
+
+func TestParseMoveRoundTrip(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	for _, move := range controller.LegalMoves() {
+		parsed, err := ParseMove(move.String())
+		if err != nil || parsed != move {
+			t.Error("Expected", move, "to survive a round trip got", parsed, err)
+		}
+	}
+}
+
+func TestParseInvalidMove(t *testing.T) {
+	invalidMoves := []string{"", "c3", "c3-", "c3e5", "c3-e5-a1", "33-e5", "c0-e5", "c3-e", "c-e5", "c3-ex"}
+
+	for _, notation := range invalidMoves {
+		if _, err := ParseMove(notation); err == nil {
+			t.Error("Expected an error when parsing", notation)
+		}
+	}
+}
+
+func TestTurnToStringAndBack(t *testing.T) {
+	turn := NewTurn(NewMove(2, 2, 3, 3), NewMove(3, 0, 3, 2))
+	if turn.String() != "c3-d4 d1-d3" {
+		t.Error("Expected c3-d4 d1-d3 got", turn.String())
+	}
+
+	parsed, err := ParseTurn(turn.String())
+	if err != nil {
+		t.Error("Expected to be able to parse", turn.String(), "got", err)
+	}
+	if parsed != turn {
+		t.Error("Expected", turn, "got", parsed)
+	}
+}
+
+func TestTurnWithoutPieceMoveToStringAndBack(t *testing.T) {
+	turn := NewTurn(NewMove(2, 2, 2, 0), Move{})
+	if turn.String() != "c3-c1" {
+		t.Error("Expected c3-c1 got", turn.String())
+	}
+
+	parsed, err := ParseTurn(turn.String())
+	if err != nil {
+		t.Error("Expected to be able to parse", turn.String(), "got", err)
+	}
+	if parsed != turn {
+		t.Error("Expected", turn, "got", parsed)
+	}
+}
+
+func TestTurnWithoutNeutrinoMoveToStringAndBack(t *testing.T) {
+	turn := NewTurn(Move{}, NewMove(0, 0, 0, 2))
+	if turn.String() != "... a1-a3" {
+		t.Error("Expected ... a1-a3 got", turn.String())
+	}
+
+	parsed, err := ParseTurn(turn.String())
+	if err != nil {
+		t.Error("Expected to be able to parse", turn.String(), "got", err)
+	}
+	if parsed != turn {
+		t.Error("Expected", turn, "got", parsed)
+	}
+}
+
+func TestParseInvalidTurn(t *testing.T) {
+	invalidTurns := []string{"", "c3", "...", "... a1", "a1-a3 ...", "c3-d4 d1-d3 a1-a2", "c3-d4 d1", "c3 d1-d3"}
+
+	for _, notation := range invalidTurns {
+		if _, err := ParseTurn(notation); err == nil {
+			t.Error("Expected an error when parsing", notation)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package game
+
+/**
+ * An immutable game of the standard size 5x5 stored in
+ * the 64 bits of GameToUInt64 along with the rules it is
+ * played by. Applying a move returns a new position and
+ * leaves the old one untouched, so positions can be shared
+ * between goroutines and kept as undo stacks without
+ * copying any Game by hand.
+ */
+type Position struct {
+	board  uint64
+	reason WinReason
+	rules  Rules
+}
+
+func StandardPosition() Position {
+	position, _ := PositionOf(NewStandardGame(), StandardRules())
+	return position
+}
+
+/**
+ * The position of the game played by the rules. As in
+ * Controller.PlayGame the rules can move the opening on
+ * to another state. Only games that can be serialized by
+ * EncodeGame have a position.
+ */
+func PositionOf(game *Game, rules Rules) (Position, error) {
+	copyOfGame := *game
+	NewController(rules).PlayGame(&copyOfGame)
+	board, err := EncodeGame(&copyOfGame)
+	if err != nil {
+		return Position{}, err
+	}
+	return Position{board: board, reason: copyOfGame.WinReason, rules: rules}, nil
+}
+
+/**
+ * Makes the move on a copy of the position. The
+ * position is returned unchanged along with the
+ * error if the move is illegal.
+ */
+func (self Position) Apply(move Move) (Position, error) {
+	game := self.Game()
+	controller := &Controller{game: game, rules: self.rules}
+	if _, err := controller.MakeMove(move); err != nil {
+		return self, err
+	}
+
+	return Position{board: GameToUInt64(game), reason: game.WinReason, rules: self.rules}, nil
+}
+
+func (self Position) LegalMoves() []Move {
+	return (&Controller{game: self.Game(), rules: self.rules}).LegalMoves()
+}
+
+//A new game in the position, changing it does not change the position
+func (self Position) Game() *Game {
+	game := UInt64ToGame(self.board)
+	game.WinReason = self.reason
+	return game
+}
+
+func (self Position) State() State {
+	return State(self.board & stateMask)
+}
+
+func (self Position) WinReason() WinReason {
+	return self.reason
+}
+
+func (self Position) Rules() Rules {
+	return self.rules
+}
+
+//The position in the format of GameToUInt64
+func (self Position) UInt64() uint64 {
+	return self.board
+}
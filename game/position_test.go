@@ -0,0 +1,121 @@
+package game
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestPositionApplyDoesNotChangePosition(t *testing.T) {
+	start := StandardPosition()
+
+	next, err := start.Apply(NewMove(2, 2, 2, 3))
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if isEqual, difference := Compare(start.Game(), NewStandardGame()); !isEqual {
+		t.Error("Expected the starting position to be unchanged got", difference)
+	}
+	if next.State() != Player1Move {
+		t.Error("Expected", Player1Move, "got", next.State())
+	}
+	if entry, _ := next.Game().GetLocation(2, 3); entry != Neutrino {
+		t.Error("Expected the neutrino to be moved got", entry)
+	}
+}
+
+func TestPositionApplyIllegalMove(t *testing.T) {
+	start := StandardPosition()
+
+	position, err := start.Apply(NewMove(0, 0, 0, 3))
+	if !errors.Is(err, ErrWrongTurn) {
+		t.Error("Expected", ErrWrongTurn, "got", err)
+	}
+	if position != start {
+		t.Error("Expected the position to be returned unchanged")
+	}
+}
+
+func TestPositionMatchesController(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+	position := StandardPosition()
+
+	for _, move := range realGameMoves {
+		checkLegalMoves(controller.LegalMoves(), position.LegalMoves(), t)
+		makeMoveAndCheckError(move.FromX, move.FromY, move.ToX, move.ToY, controller, t)
+
+		var err error
+		if position, err = position.Apply(move); err != nil {
+			t.Fatal("Expected no error for", move, "got", err)
+		}
+		if isEqual, difference := Compare(game, position.Game()); !isEqual {
+			t.Fatal("Expected the position to match the controller after", move, "got", difference)
+		}
+	}
+	if position.State() != Player2Win || position.WinReason() != game.WinReason {
+		t.Error("Expected", Player2Win, "by", game.WinReason, "got", position.State(), "by", position.WinReason())
+	}
+}
+
+func TestPositionOf(t *testing.T) {
+	position, err := PositionOf(NewStandardGame(), OfficialRules())
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if position.State() != Player1Move {
+		t.Error("Expected the official rules to skip the first neutrino move got", position.State())
+	}
+	if position.Rules() != OfficialRules() {
+		t.Error("Expected the rules to be kept got", position.Rules())
+	}
+
+	if _, err := PositionOf(NewLargeGame(), StandardRules()); err == nil {
+		t.Error("Expected an error for a game that cannot be serialized")
+	}
+}
+
+func TestPositionExtraNeutrinoMove(t *testing.T) {
+	game, _ := NewHandicapGame(HandicapExtraNeutrinoMove)
+	position, _ := PositionOf(game, HandicapRules(HandicapExtraNeutrinoMove))
+
+	position, _ = position.Apply(NewMove(2, 2, 2, 3))
+	position, _ = position.Apply(NewMove(2, 3, 2, 1))
+	position, _ = position.Apply(NewMove(0, 0, 0, 3))
+	position, err := position.Apply(NewMove(2, 1, 2, 3))
+	if err != nil {
+		t.Error("Expected no error got", err)
+	}
+	if position.State() != Player2Move {
+		t.Error("Expected only the first neutrino move of player 2 to be extra,", Player2Move, "got", position.State())
+	}
+	if position.Rules() != HandicapRules(HandicapExtraNeutrinoMove) {
+		t.Error("Expected the rules to be kept got", position.Rules())
+	}
+}
+
+func TestPositionSharedBetweenGoroutines(t *testing.T) {
+	start := StandardPosition()
+	moves := start.LegalMoves()
+
+	var wg sync.WaitGroup
+	results := make([]Position, len(moves))
+	for i, move := range moves {
+		wg.Add(1)
+		go func(i int, move Move) {
+			defer wg.Done()
+			results[i], _ = start.Apply(move)
+		}(i, move)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if result.State() != Player1Move {
+			t.Error("Expected", Player1Move, "after", moves[i], "got", result.State())
+		}
+	}
+	if start != StandardPosition() {
+		t.Error("Expected the shared position to be unchanged")
+	}
+}
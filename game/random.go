@@ -0,0 +1,100 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const defaultRandomGameAttempts = 1000
+
+/**
+ * Options for NewRandomGame, the zero value gives
+ * a standard 5x5 board played by the standard rules
+ */
+type RandomGameOptions struct {
+	//The rules the first turn is checked by
+	Rules Rules
+	//Size of the board, zero means the standard size.
+	//The height must be odd and at least 5 so there is
+	//a middle row between the back rows of the players
+	Width, Height byte
+	//How many positions to try before giving up,
+	//zero means 1000
+	MaxAttempts int
+}
+
+/**
+ * A shuffled starting position where the pieces of
+ * player 1 are placed at random within their two back
+ * rows, the pieces of player 2 mirror them and the
+ * neutrino is placed at random on the middle row.
+ * Positions where player 1 can win on the first turn
+ * are rejected. The same seed and options always give
+ * the same game.
+ */
+func NewRandomGame(seed int64, opts RandomGameOptions) (*Game, error) {
+	width, height := opts.Width, opts.Height
+	if width == 0 {
+		width = standardBoardSize
+	}
+	if height == 0 {
+		height = standardBoardSize
+	}
+	if height < standardBoardSize || height%2 == 0 {
+		return nil, fmt.Errorf("Height of a random game must be odd and at least %d. Was %d", standardBoardSize, height)
+	}
+	attempts := opts.MaxAttempts
+	if attempts == 0 {
+		attempts = defaultRandomGameAttempts
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	for i := 0; i < attempts; i++ {
+		game, err := NewEmptyGameOfSize(width, height)
+		if err != nil {
+			return nil, err
+		}
+		//The squares of the two back rows are numbered from
+		//the home row and out
+		for _, square := range random.Perm(2 * int(width))[:width] {
+			x, y := byte(square)%width, byte(square)/width
+			game.SetLocation(x, y, Player1)
+			game.SetLocation(x, height-1-y, Player2)
+		}
+		game.SetLocation(byte(random.Intn(int(width))), height/2, Neutrino)
+		game.opening = true
+
+		if !canWinOnFirstTurn(game, opts.Rules) {
+			return game, nil
+		}
+	}
+	return nil, fmt.Errorf("Could not find a random game where player 1 cannot win on the first turn in %d attempts", attempts)
+}
+
+func canWinOnFirstTurn(game *Game, rules Rules) bool {
+	copyOfGame := *game
+	controller := NewController(rules)
+	controller.PlayGame(&copyOfGame)
+	return canWinThisTurn(controller)
+}
+
+/**
+ * Tries every move of the player to move, including
+ * the piece moves following a neutrino move, and takes
+ * each of them back again
+ */
+func canWinThisTurn(controller *Controller) bool {
+	player := controller.Game().State.Player()
+	for _, move := range controller.LegalMoves() {
+		state, _ := controller.MakeMove(move)
+		won := state == statesOfPlayer[player].win
+		if !state.IsWin() && state.Player() == player {
+			won = canWinThisTurn(controller)
+		}
+		controller.Undo()
+		if won {
+			return true
+		}
+	}
+	return false
+}
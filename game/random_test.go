@@ -0,0 +1,102 @@
+package game
+
+import "testing"
+
+func TestRandomGameIsReproducible(t *testing.T) {
+	game, err := NewRandomGame(42, RandomGameOptions{})
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	sameGame, _ := NewRandomGame(42, RandomGameOptions{})
+	if isEqual, difference := Compare(game, sameGame); !isEqual {
+		t.Error("Expected the same game for the same seed got", difference)
+	}
+
+	different := false
+	for seed := int64(0); seed < 10; seed++ {
+		otherGame, _ := NewRandomGame(seed, RandomGameOptions{})
+		if isEqual, _ := Compare(game, otherGame); !isEqual {
+			different = true
+		}
+	}
+	if !different {
+		t.Error("Expected different seeds to give different games")
+	}
+}
+
+func TestRandomGameIsMirrored(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		game, err := NewRandomGame(seed, RandomGameOptions{})
+		if err != nil {
+			t.Fatal("Expected no error got", err)
+		}
+		if err := game.Validate(); err != nil {
+			t.Error("Expected a valid game for seed", seed, "got", err)
+		}
+		if game.State != Player1NeutrinoMove {
+			t.Error("Expected", Player1NeutrinoMove, "got", game.State)
+		}
+
+		pieces := 0
+		for y := byte(0); y < 2; y++ {
+			for x := byte(0); x < 5; x++ {
+				entry, _ := game.GetLocation(x, y)
+				mirrored, _ := game.GetLocation(x, 4-y)
+				if entry == Player1 {
+					pieces++
+				}
+				if (entry == Player1) != (mirrored == Player2) {
+					t.Error("Expected player 2 to mirror player 1 at", x, y, "for seed", seed)
+				}
+			}
+		}
+		if pieces != 5 {
+			t.Error("Expected 5 pieces in the back rows of player 1 got", pieces, "for seed", seed)
+		}
+
+		neutrinos := (&Controller{game: game}).locateNeutrinos()
+		if len(neutrinos) != 1 || neutrinos[0].y != 2 {
+			t.Error("Expected the neutrino on the middle row got", neutrinos, "for seed", seed)
+		}
+	}
+}
+
+func TestRandomGameCannotBeWonOnFirstTurn(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		game, _ := NewRandomGame(seed, RandomGameOptions{})
+		controller := NewController(StandardRules())
+		controller.PlayGame(game)
+
+		for _, neutrinoMove := range controller.LegalMoves() {
+			state, _ := controller.MakeMove(neutrinoMove)
+			if state == Player1Win {
+				t.Error("Expected player 1 to not win by", neutrinoMove, "for seed", seed)
+			}
+			if state == Player1Move {
+				for _, pieceMove := range controller.LegalMoves() {
+					if state, _ := controller.MakeMove(pieceMove); state == Player1Win {
+						t.Error("Expected player 1 to not win by", neutrinoMove, pieceMove, "for seed", seed)
+					}
+					controller.Undo()
+				}
+			}
+			controller.Undo()
+		}
+	}
+}
+
+func TestRandomGameOfSize(t *testing.T) {
+	game, err := NewRandomGame(7, RandomGameOptions{Width: 7, Height: 7})
+	if err != nil {
+		t.Fatal("Expected no error got", err)
+	}
+	if game.Width() != 7 || game.Height() != 7 {
+		t.Error("Expected a 7x7 game got", game.Width(), game.Height())
+	}
+
+	for _, opts := range []RandomGameOptions{{Height: 4}, {Height: 3}, {Width: 8, Height: 9}} {
+		if _, err := NewRandomGame(7, opts); err == nil {
+			t.Error("Expected an error for the options", opts)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/**
+ * A record of a played game in a format similar to
+ * the PGN format used for chess. Header tags are
+ * followed by the numbered turns and the result:
+ *
+ *   [Event "Club night"]
+ *   [Date "2016.05.21"]
+ *   [Player1 "Alice"]
+ *   [Player2 "Bob"]
+ *   [Result "0-1"]
+ *   [Handicap "OnePiece"]
+ *   [Position "xx1xx/5/2n2/5/ooooo 1n"]
+ *
+ *   1. c3-d4 d1-d3 2. d4-e4 c5-e3 0-1
+ *
+ * A number starts the turn of a player, which is the
+ * neutrino move followed by the piece move. A turn
+ * without a neutrino move is numbered with "..."
+ */
+
+const (
+	ResultPlayer1Win = "1-0"
+	ResultPlayer2Win = "0-1"
+	//The four player variant scores every player in the
+	//order player 1, 2, 3 and 4
+	ResultPlayer3Win = "0-0-1-0"
+	ResultPlayer4Win = "0-0-0-1"
+	ResultUnfinished = "*"
+)
+
+const (
+	tagEvent    = "Event"
+	tagDate     = "Date"
+	tagPlayer1  = "Player1"
+	tagPlayer2  = "Player2"
+	tagResult   = "Result"
+	tagHandicap = "Handicap"
+	tagPosition = "Position"
+
+	recordLineLength = 80
+)
+
+//// Record type ////
+
+type Record struct {
+	Event   string
+	Date    string
+	Player1 string
+	Player2 string
+	Result  string
+	//The handicap the game was played with, the
+	//game is replayed by HandicapRules
+	Handicap Handicap
+	//The starting position, nil means NewHandicapGame.
+	//It is written in the format of Game.MarshalText
+	Start *Game
+	Moves []Move
+	//Any tags that are not one of the above
+	Tags map[string]string
+}
+
+func ResultForState(state State) string {
+	switch state {
+	case Player1Win:
+		return ResultPlayer1Win
+	case Player2Win:
+		return ResultPlayer2Win
+	case Player3Win:
+		return ResultPlayer3Win
+	case Player4Win:
+		return ResultPlayer4Win
+	default:
+		return ResultUnfinished
+	}
+}
+
+func isResult(token string) bool {
+	switch token {
+	case ResultPlayer1Win, ResultPlayer2Win, ResultPlayer3Win, ResultPlayer4Win, ResultUnfinished:
+		return true
+	default:
+		return false
+	}
+}
+
+/**
+ * Error returned from Replay containing the
+ * ply number, counting from 1, of the first
+ * move that could not be made
+ */
+type ReplayError struct {
+	Ply  int
+	Move Move
+	Err  error
+}
+
+func (self *ReplayError) Error() string {
+	return fmt.Sprintf("Illegal move %v at ply %d: %s", self.Move, self.Ply, self.Err)
+}
+
+func (self *ReplayError) Unwrap() error {
+	return self.Err
+}
+
+/**
+ * Plays the moves of the record from the starting
+ * position through a Controller. On an illegal move
+ * the game is returned as it was before that move
+ * along with a ReplayError.
+ */
+func (self *Record) Replay() (*Game, error) {
+	return self.ReplayWithRules(HandicapRules(self.Handicap))
+}
+
+func (self *Record) ReplayWithRules(rules Rules) (*Game, error) {
+	controller := NewController(rules)
+	controller.PlayGame(self.startingGame())
+	for i, move := range self.Moves {
+		if _, err := controller.MakeMove(move); err != nil {
+			return controller.Game(), &ReplayError{Ply: i + 1, Move: move, Err: err}
+		}
+	}
+	return controller.Game(), nil
+}
+
+func (self *Record) startingGame() *Game {
+	if self.Start == nil {
+		if game, err := NewHandicapGame(self.Handicap); err == nil {
+			return game
+		}
+		return NewStandardGame()
+	}
+	start := *self.Start
+	return &start
+}
+
+//// Writing ////
+
+func WriteRecord(w io.Writer, record *Record) error {
+	bw := bufio.NewWriter(w)
+
+	result := record.Result
+	if result == "" {
+		result = ResultUnfinished
+	}
+	writeTag(bw, tagEvent, record.Event)
+	writeTag(bw, tagDate, record.Date)
+	writeTag(bw, tagPlayer1, record.Player1)
+	writeTag(bw, tagPlayer2, record.Player2)
+	writeTag(bw, tagResult, result)
+	if record.Handicap != NoHandicap {
+		writeTag(bw, tagHandicap, record.Handicap.String())
+	}
+	if record.Start != nil {
+		position, err := record.Start.MarshalText()
+		if err != nil {
+			return err
+		}
+		writeTag(bw, tagPosition, string(position))
+	}
+	names := make([]string, 0, len(record.Tags))
+	for name := range record.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeTag(bw, name, record.Tags[name])
+	}
+	bw.WriteString("\n")
+
+	lineLength := 0
+	for _, token := range append(record.moveTokens(), result) {
+		if lineLength > 0 && lineLength+1+len(token) > recordLineLength {
+			bw.WriteString("\n")
+			lineLength = 0
+		} else if lineLength > 0 {
+			bw.WriteString(" ")
+			lineLength++
+		}
+		bw.WriteString(token)
+		lineLength += len(token)
+	}
+	bw.WriteString("\n")
+	return bw.Flush()
+}
+
+func writeTag(w *bufio.Writer, name, value string) {
+	fmt.Fprintf(w, "[%s %s]\n", name, strconv.Quote(value))
+}
+
+/**
+ * Follows the pieces on the board without checking
+ * the rules so that moving the neutrino can start a
+ * new numbered turn even if the record is illegal
+ */
+func (self *Record) moveTokens() []string {
+	board := self.startingGame()
+	tokens := make([]string, 0, len(self.Moves))
+	turn := 0
+	afterNeutrinoMove := false
+	for _, move := range self.Moves {
+		entry, _ := board.GetLocation(move.FromX, move.FromY)
+		//The turn number is kept with the move so a line
+		//is never broken between the two
+		if entry == Neutrino {
+			turn++
+			tokens = append(tokens, strconv.Itoa(turn)+". "+move.String())
+			afterNeutrinoMove = true
+		} else if !afterNeutrinoMove {
+			turn++
+			tokens = append(tokens, strconv.Itoa(turn)+"... "+move.String())
+		} else {
+			tokens = append(tokens, move.String())
+			afterNeutrinoMove = false
+		}
+		board.SetLocation(move.FromX, move.FromY, EmptySquare)
+		board.SetLocation(move.ToX, move.ToY, entry)
+	}
+	return tokens
+}
+
+//// Reading ////
+
+func ReadRecord(r io.Reader) (*Record, error) {
+	record := &Record{Result: ResultUnfinished}
+	scanner := bufio.NewScanner(r)
+	var moveText []string
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && len(moveText) == 0 {
+			if err := record.readTag(line); err != nil {
+				return nil, fmt.Errorf("Line %d: %s", lineNumber, err)
+			}
+		} else if line != "" {
+			moveText = append(moveText, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, token := range strings.Fields(strings.Join(moveText, " ")) {
+		if isResult(token) {
+			record.Result = token
+			continue
+		}
+		//Strip turn numbers such as "12." and "1..."
+		if i := strings.LastIndex(token, "."); i >= 0 {
+			if _, err := strconv.Atoi(strings.TrimRight(token[:i+1], ".")); err != nil {
+				return nil, fmt.Errorf("Invalid turn number %q", token)
+			}
+			token = token[i+1:]
+			if token == "" {
+				continue
+			}
+		}
+		move, err := ParseMove(token)
+		if err != nil {
+			return nil, err
+		}
+		record.Moves = append(record.Moves, move)
+	}
+	return record, nil
+}
+
+func (self *Record) readTag(line string) error {
+	if !strings.HasSuffix(line, "]") {
+		return fmt.Errorf("Tag must end with ']'. Was %q", line)
+	}
+	parts := strings.SplitN(line[1:len(line)-1], " ", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("Tag must be a name followed by a quoted value. Was %q", line)
+	}
+	name := parts[0]
+	value, err := strconv.Unquote(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return fmt.Errorf("Tag must be a name followed by a quoted value. Was %q", line)
+	}
+
+	switch name {
+	case tagEvent:
+		self.Event = value
+	case tagDate:
+		self.Date = value
+	case tagPlayer1:
+		self.Player1 = value
+	case tagPlayer2:
+		self.Player2 = value
+	case tagResult:
+		self.Result = value
+	case tagHandicap:
+		handicap, err := ParseHandicap(value)
+		if err != nil {
+			return err
+		}
+		self.Handicap = handicap
+	case tagPosition:
+		self.Start = &Game{}
+		if err := self.Start.UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+	default:
+		if self.Tags == nil {
+			self.Tags = map[string]string{}
+		}
+		self.Tags[name] = value
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var realGameMoves = []Move{
+	NewMove(2, 2, 3, 3), NewMove(3, 0, 3, 2),
+	NewMove(3, 3, 4, 3), NewMove(2, 4, 4, 2),
+	NewMove(4, 3, 0, 3), NewMove(2, 0, 3, 0),
+	NewMove(0, 3, 4, 3), NewMove(1, 4, 2, 3),
+	NewMove(4, 3, 3, 3), NewMove(0, 0, 2, 2),
+	NewMove(3, 3, 4, 3), NewMove(2, 3, 3, 3)}
+
+const realGameRecord = `[Event "Club night"]
+[Date "2016.05.21"]
+[Player1 "Alice"]
+[Player2 "Bob"]
+[Result "0-1"]
+
+1. c3-d4 d1-d3 2. d4-e4 c5-e3 3. e4-a4 c1-d1 4. a4-e4 b5-c4 5. e4-d4 a1-c3
+6. d4-e4 c4-d4 0-1
+`
+
+func TestWriteRecord(t *testing.T) {
+	record := &Record{
+		Event:   "Club night",
+		Date:    "2016.05.21",
+		Player1: "Alice",
+		Player2: "Bob",
+		Result:  ResultPlayer2Win,
+		Moves:   realGameMoves,
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteRecord(&buffer, record); err != nil {
+		t.Error("Expected to be able to write the record, got", err)
+	}
+	if buffer.String() != realGameRecord {
+		t.Errorf("Expected the record\n%s\ngot\n%s", realGameRecord, buffer.String())
+	}
+}
+
+func TestReadRecord(t *testing.T) {
+	record, err := ReadRecord(strings.NewReader(realGameRecord))
+	if err != nil {
+		t.Fatal("Expected to be able to read the record, got", err)
+	}
+	if record.Event != "Club night" || record.Date != "2016.05.21" ||
+		record.Player1 != "Alice" || record.Player2 != "Bob" || record.Result != ResultPlayer2Win {
+		t.Error("Expected the tags to be read, got", record)
+	}
+	if len(record.Moves) != len(realGameMoves) {
+		t.Fatal("Expected", realGameMoves, "got", record.Moves)
+	}
+	for i, move := range realGameMoves {
+		if record.Moves[i] != move {
+			t.Error("Expected", move, "at ply", i+1, "got", record.Moves[i])
+		}
+	}
+}
+
+func TestReplayRecord(t *testing.T) {
+	record, _ := ReadRecord(strings.NewReader(realGameRecord))
+
+	game, err := record.Replay()
+	if err != nil {
+		t.Error("Expected to be able to replay the record, got", err)
+	}
+	if ResultForState(game.State) != record.Result {
+		t.Error("Expected the replay to end in", record.Result, "got", ResultForState(game.State))
+	}
+}
+
+func TestResultForState(t *testing.T) {
+	results := map[State]string{
+		Player1Win:          ResultPlayer1Win,
+		Player2Win:          ResultPlayer2Win,
+		Player3Win:          ResultPlayer3Win,
+		Player4Win:          ResultPlayer4Win,
+		Player1NeutrinoMove: ResultUnfinished,
+		Player3Move:         ResultUnfinished,
+	}
+	for state, expected := range results {
+		if result := ResultForState(state); result != expected {
+			t.Error("Expected", expected, "for state", state, "got", result)
+		}
+	}
+}
+
+func TestReadFourPlayerResult(t *testing.T) {
+	for _, result := range []string{ResultPlayer3Win, ResultPlayer4Win} {
+		record, err := ReadRecord(strings.NewReader("1. d4-d3 d1-c2 " + result + "\n"))
+		if err != nil {
+			t.Fatal("Expected to be able to read the record, got", err)
+		}
+		if record.Result != result || len(record.Moves) != 2 {
+			t.Error("Expected two moves and the result", result, "got", record.Moves, record.Result)
+		}
+	}
+}
+
+func TestReplayReportsFirstIllegalMove(t *testing.T) {
+	record := &Record{Moves: []Move{
+		NewMove(2, 2, 3, 3), NewMove(3, 0, 3, 2),
+		NewMove(3, 3, 4, 3), NewMove(2, 4, 2, 3)}}
+
+	game, err := record.Replay()
+	var replayError *ReplayError
+	if !errors.As(err, &replayError) {
+		t.Fatal("Expected a ReplayError, got", err)
+	}
+	if replayError.Ply != 4 || replayError.Move != NewMove(2, 4, 2, 3) {
+		t.Error("Expected the fourth move to be illegal, got", replayError)
+	}
+	if !errors.Is(err, ErrDidNotSlideToObstacle) {
+		t.Error("Expected the cause to be", ErrDidNotSlideToObstacle, "got", replayError.Err)
+	}
+	if game.State != Player2Move {
+		t.Error("Expected the game to be left before the illegal move in", Player2Move, "got", game.State)
+	}
+}
+
+func TestRecordRoundTripWithStartAndTags(t *testing.T) {
+	start, _ := SetupEmptyGame()
+	start.SetLocation(2, 2, Neutrino)
+	start.SetLocation(0, 0, Player1)
+	start.SetLocation(4, 4, Player2)
+	start.State = Player1Move
+	record := &Record{
+		Player1: "Alice \"The Wall\"",
+		Start:   start,
+		Moves:   []Move{NewMove(0, 0, 4, 0), NewMove(2, 2, 2, 0)},
+		Tags:    map[string]string{"Round": "3", "Site": "Aarhus"},
+	}
+
+	var buffer bytes.Buffer
+	WriteRecord(&buffer, record)
+	if !strings.Contains(buffer.String(), "1... a1-e1 2. c3-c1") {
+		t.Error("Expected the first turn to have no neutrino move, got", buffer.String())
+	}
+
+	read, err := ReadRecord(&buffer)
+	if err != nil {
+		t.Fatal("Expected to be able to read the record, got", err)
+	}
+	if read.Player1 != record.Player1 {
+		t.Error("Expected", record.Player1, "got", read.Player1)
+	}
+	if read.Tags["Round"] != "3" || read.Tags["Site"] != "Aarhus" {
+		t.Error("Expected the extra tags to be kept, got", read.Tags)
+	}
+	if read.Start == nil {
+		t.Fatal("Expected a starting position")
+	}
+	if result, message := Compare(start, read.Start); !result {
+		t.Error("Expected the starting position to be kept: ", message)
+	}
+	if read.Result != ResultUnfinished {
+		t.Error("Expected", ResultUnfinished, "got", read.Result)
+	}
+
+	game, err := read.Replay()
+	if err != nil {
+		t.Error("Expected to be able to replay the record, got", err)
+	}
+	if game.State != Player2Win {
+		t.Error("Expected", Player2Win, "got", game.State)
+	}
+	if start.State != Player1Move {
+		t.Error("Expected the replay to not change the starting position")
+	}
+}
+
+func TestReadInvalidRecord(t *testing.T) {
+	invalidRecords := []string{
+		"[Event Club night]\n\n1. c3-d4 *",
+		"[Event \"Club night\"\n\n1. c3-d4 *",
+		"[Position \"a lot\"]\n\n1. c3-d4 *",
+		"1. c3-d4 d1=d3 *",
+		"one. c3-d4 *"}
+
+	for _, text := range invalidRecords {
+		if _, err := ReadRecord(strings.NewReader(text)); err == nil {
+			t.Error("Expected an error when reading", text)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package game
+
+import "fmt"
+
+/**
+ * The rule details that differ between the official
+ * rules and the house rules played in some clubs. The
+ * zero value is the standard rules of this package.
+ */
+type Rules struct {
+	//Player 1 moves a piece instead of the neutrino on
+	//the very first turn, as in the official rules
+	SkipFirstNeutrinoMove bool
+	//Lets a player move all of their pieces back on
+	//their own home row
+	AllowFullHomeRow bool
+	//Who wins when a player cannot move the neutrino
+	TrappedNeutrino TrappedNeutrinoScoring
+	//Pieces sliding off the left or right edge continue
+	//from the opposite edge
+	WrapColumns bool
+	//Pieces sliding off the top or bottom edge continue
+	//from the opposite edge. The neutrino does not wrap
+	//so it still stops on the home rows
+	WrapRows bool
+	//The number of neutrinos on the board, zero means one.
+	//Each neutrino phase moves one neutrino of the player's
+	//choice
+	Neutrinos byte
+	//The number of players, zero means two. With four
+	//players the east and west edges are the home edges
+	//of player 3 and 4
+	Players byte
+	//The handicap the game was set up with, see
+	//NewHandicapGame
+	Handicap Handicap
+	//Moving the neutrino to a home row loses for the player
+	//that moved it and trapping the neutrino loses for the
+	//trapper, whatever TrappedNeutrino is set to
+	Misere bool
+}
+
+func (self Rules) neutrinoCount() int {
+	if self.Neutrinos == 0 {
+		return 1
+	}
+	return int(self.Neutrinos)
+}
+
+type TrappedNeutrinoScoring byte
+
+const (
+	//The player that trapped the neutrino wins
+	TrappedNeutrinoWinsForTrapper TrappedNeutrinoScoring = iota
+	//The player that trapped the neutrino loses
+	TrappedNeutrinoLosesForTrapper
+)
+
+func StandardRules() Rules {
+	return Rules{}
+}
+
+func OfficialRules() Rules {
+	return Rules{SkipFirstNeutrinoMove: true}
+}
+
+/**
+ * The torus variant where pieces wrap around the left and
+ * right edges, and with wrapRows also the top and bottom
+ */
+func TorusRules(wrapRows bool) Rules {
+	return Rules{WrapColumns: true, WrapRows: wrapRows}
+}
+
+/**
+ * The order the players take their turns in, going
+ * clockwise around the board from player 1 on the north edge
+ */
+func (self Rules) turnOrder() []Entry {
+	if self.Players == 4 {
+		return []Entry{Player1, Player3, Player2, Player4}
+	}
+	return []Entry{Player1, Player2}
+}
+
+//Whether the player takes turns, without allocating the turn order
+func (self Rules) isPlaying(player Entry) bool {
+	switch player {
+	case Player1, Player2:
+		return true
+	case Player3, Player4:
+		return self.Players == 4
+	default:
+		return false
+	}
+}
+
+func (self Rules) nextPlayer(player Entry) Entry {
+	order := self.turnOrder()
+	for i, other := range order {
+		if other == player {
+			return order[(i+1)%len(order)]
+		}
+	}
+	//We should never get here
+	panic(fmt.Sprintf("Player %d is not playing the game", player))
+}
+
+func (self Rules) previousPlayer(player Entry) Entry {
+	order := self.turnOrder()
+	for i, other := range order {
+		if other == player {
+			return order[(i+len(order)-1)%len(order)]
+		}
+	}
+	//We should never get here
+	panic(fmt.Sprintf("Player %d is not playing the game", player))
+}
+
+/**
+ * The variant played with several neutrinos, see
+ * NewMultiNeutrinoGame for the starting position
+ */
+func MultiNeutrinoRules(neutrinos byte) Rules {
+	return Rules{Neutrinos: neutrinos}
+}
+
+/**
+ * The four player variant, see NewFourPlayerGame
+ * for the starting position
+ */
+func FourPlayerRules() Rules {
+	return Rules{Players: 4}
+}
+
+/**
+ * The misère variant where the win conditions are
+ * reversed, see Rules.Misere
+ */
+func MisereRules() Rules {
+	return Rules{Misere: true, TrappedNeutrino: TrappedNeutrinoLosesForTrapper}
+}
@@ -1,61 +1,118 @@
 package game
 
-import (
-	"fmt"
-	"strconv"
-)
+import "fmt"
+
+/**
+ * A game of the standard size 5x5 is stored in 64
+ * bits. The first 11 bits is the version of the format,
+ * followed by 2 bits for each entry row by row and 3
+ * bits for the state.
+ *
+ * Values stored before the version was introduced have
+ * version 0, which has the same layout as version 1.
+ */
 
-var (
-	serializerPrefixLength = 11
+const (
+	serializerVersion = 1
+	versionShift      = 53
+	entryBits         = 2
+	entryMask         = 1<<entryBits - 1
+	stateMask         = 1<<3 - 1
 )
 
+/**
+ * Serializes the game and panics if it cannot be
+ * serialized, e.g. if it is not 5x5. Use EncodeGame
+ * for an error instead.
+ */
 func GameToUInt64(game *Game) uint64 {
-	bits := ""
+	output, err := EncodeGame(game)
+	if err != nil {
+		panic(err)
+	}
+	return output
+}
+
+func EncodeGame(game *Game) (uint64, error) {
+	if game.Width() != standardBoardSize || game.Height() != standardBoardSize {
+		return 0, fmt.Errorf("Only games of size %dx%d can be serialized. Was %dx%d", standardBoardSize, standardBoardSize, game.Width(), game.Height())
+	}
+	output := uint64(serializerVersion) << versionShift
 
 	//The entries are stored by rows, so
 	//we must pass through each column before
 	//moving on to the next row
+	shift := uint(versionShift)
 	for y := byte(0); y < 5; y++ {
 		for x := byte(0); x < 5; x++ {
 			entry, _ := game.GetLocation(x, y)
-			entryAsBits := strconv.FormatUint(uint64(entry), 2)
-			bits += fmt.Sprintf("%02s", entryAsBits)
+			if entry > Neutrino {
+				return 0, fmt.Errorf("Cannot serialize the entry %d at (%d, %d)", entry, x, y)
+			}
+			shift -= entryBits
+			output |= uint64(entry) << shift
 		}
 	}
 
-	stateAsBits := strconv.FormatUint(uint64(game.State), 2)
-	bits += fmt.Sprintf("%03s", stateAsBits)
-	bits = fmt.Sprintf("%064s", bits)
-
-	output, _ := strconv.ParseUint(bits, 2, 64)
-	//todo do something with the error.
-	return output
+	if game.State > Player2Win {
+		return 0, fmt.Errorf("%w %d", ErrInvalidState, game.State)
+	}
+	return output | uint64(game.State), nil
 }
 
+/**
+ * Deserializes the game without checking it,
+ * see DecodeGame for a checked version
+ */
 func UInt64ToGame(input uint64) *Game {
 	game := &Game{}
+	UInt64IntoGame(input, game)
+	return game
+}
 
-	bits := strconv.FormatUint(input, 2)
-	bits = fmt.Sprintf("%064s", bits)
-
-	var x byte = 0
-	var y byte = 0
-	for i := 0; i < 50; i += 2 {
-		entryAsBits := bits[serializerPrefixLength+i : serializerPrefixLength+i+2]
-		//TODO do something with the error
-		entry, _ := strconv.ParseUint(entryAsBits, 2, 8)
-		game.SetLocation(x, y, Entry(entry))
-
-		x++
-		if x > 4 {
-			x = 0
-			y++
-		}
+/**
+ * Deserializes the game and makes sure the version
+ * is known and that the game is valid by the standard
+ * rules, see DecodeGameFor for variants
+ */
+func DecodeGame(input uint64) (*Game, error) {
+	return DecodeGameFor(input, StandardRules())
+}
+
+/**
+ * Deserializes the game and makes sure the version
+ * is known and that the game is valid by the rules
+ */
+func DecodeGameFor(input uint64, rules Rules) (*Game, error) {
+	if version := input >> versionShift; version > serializerVersion {
+		return nil, fmt.Errorf("%w %d", ErrUnknownSerializerVersion, version)
 	}
 
-	stateAsBits := bits[61:64]
-	//TODO do something with the error
-	state, _ := strconv.ParseUint(stateAsBits, 2, 8)
-	game.State = State(state)
-	return game
+	game := UInt64ToGame(input)
+	if err := game.ValidateFor(rules); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+/**
+ * Deserializes into an existing game to avoid
+ * allocating a new one. The win reason is not
+ * stored so it is reset to NoWinner.
+ */
+func UInt64IntoGame(input uint64, game *Game) {
+	if game.Width() != standardBoardSize || game.Height() != standardBoardSize {
+		//Clear the squares outside of the smaller board
+		*game = Game{}
+	}
+	game.WinReason = NoWinner
+	game.width, game.height = standardBoardSize, standardBoardSize
+	shift := uint(versionShift)
+	for y := byte(0); y < 5; y++ {
+		for x := byte(0); x < 5; x++ {
+			shift -= entryBits
+			game.SetLocation(x, y, Entry(input>>shift&entryMask))
+		}
+	}
+	game.State = State(input & stateMask)
 }
@@ -1,6 +1,9 @@
 package game
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestSerialization_SquaredGame(t *testing.T) {
 	referenceGame, _ := SetupSquaredGame()
@@ -68,3 +71,182 @@ func makeMoveAndCheckError(fromX, fromY, toX, toY byte, controller *Controller,
 		t.Error("Invalid move: ", err.Error())
 	}
 }
+
+func TestSerializationStoresVersion(t *testing.T) {
+	intRepresentation := GameToUInt64(NewStandardGame())
+	if version := intRepresentation >> 53; version != 1 {
+		t.Error("Expected version", 1, "got", version)
+	}
+}
+
+func TestDecodeGame(t *testing.T) {
+	referenceGame := NewStandardGame()
+	game, err := DecodeGame(GameToUInt64(referenceGame))
+	if err != nil {
+		t.Error("Expected to be able to decode the game, got", err)
+	}
+	if result, message := Compare(referenceGame, game); !result {
+		t.Error("Decoded game does not match game that was encoded: ", message)
+	}
+}
+
+func TestDecodeGameWithoutVersion(t *testing.T) {
+	referenceGame := NewStandardGame()
+	withoutVersion := GameToUInt64(referenceGame) &^ (uint64(0x7FF) << 53)
+	game, err := DecodeGame(withoutVersion)
+	if err != nil {
+		t.Error("Expected to be able to decode a game stored before versioning, got", err)
+	}
+	if result, message := Compare(referenceGame, game); !result {
+		t.Error("Decoded game does not match game that was encoded: ", message)
+	}
+}
+
+func TestDecodeGameWithUnknownVersion(t *testing.T) {
+	intRepresentation := GameToUInt64(NewStandardGame()) | uint64(1)<<54
+	if _, err := DecodeGame(intRepresentation); !errors.Is(err, ErrUnknownSerializerVersion) {
+		t.Error("Expected", ErrUnknownSerializerVersion, "got", err)
+	}
+}
+
+func TestDecodeGameWithInvalidState(t *testing.T) {
+	for _, state := range []uint64{6, 7} {
+		intRepresentation := GameToUInt64(NewStandardGame())&^7 | state
+		if _, err := DecodeGame(intRepresentation); !errors.Is(err, ErrInvalidState) {
+			t.Error("Expected", ErrInvalidState, "for state", state, "got", err)
+		}
+	}
+}
+
+func TestDecodeGameWithoutNeutrino(t *testing.T) {
+	game, _ := SetupEmptyGame()
+	if _, err := DecodeGame(GameToUInt64(game)); err != ErrNoNeutrinoInGame {
+		t.Error("Expected", ErrNoNeutrinoInGame, "got", err)
+	}
+}
+
+func TestDecodeGameForMisere(t *testing.T) {
+	game := &Game{}
+	game.UnmarshalText([]byte("xxxxx/5/2n2/5/oo1oo 1n"))
+	controller := NewController(MisereRules())
+	controller.PlayGame(game)
+	makeMoveAndCheckError(2, 2, 2, 4, controller, t)
+
+	if _, err := DecodeGame(GameToUInt64(game)); err == nil {
+		t.Error("Expected the misère win to be invalid by the standard rules")
+	}
+	decoded, err := DecodeGameFor(GameToUInt64(game), MisereRules())
+	if err != nil {
+		t.Error("Expected to be able to decode the misère win, got", err)
+	}
+	if result, message := Compare(game, decoded); !result {
+		t.Error("Decoded game does not match game that was encoded: ", message)
+	}
+}
+
+func TestDecodeGameForMultipleNeutrinos(t *testing.T) {
+	game := &Game{}
+	game.UnmarshalText([]byte("xxxxx/5/1n1n1/5/ooooo 1n"))
+
+	if _, err := DecodeGame(GameToUInt64(game)); err == nil {
+		t.Error("Expected two neutrinos to be invalid by the standard rules")
+	}
+	decoded, err := DecodeGameFor(GameToUInt64(game), MultiNeutrinoRules(2))
+	if err != nil {
+		t.Error("Expected to be able to decode two neutrinos, got", err)
+	}
+	if result, message := Compare(game, decoded); !result {
+		t.Error("Decoded game does not match game that was encoded: ", message)
+	}
+}
+
+func TestUInt64IntoWonGame(t *testing.T) {
+	game := &Game{}
+	game.UnmarshalText([]byte("xxxxx/5/2n2/5/oo1oo 1n"))
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+	makeMoveAndCheckError(2, 2, 2, 4, controller, t)
+
+	UInt64IntoGame(GameToUInt64(NewStandardGame()), game)
+	if game.WinReason != NoWinner {
+		t.Error("Expected", NoWinner, "got", game.WinReason)
+	}
+	if err := game.Validate(); err != nil {
+		t.Error("Expected the decoded game to be valid got", err)
+	}
+}
+
+func TestGameToUInt64PanicsForLargeGame(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic when serializing a 7x7 game")
+		}
+	}()
+	GameToUInt64(NewLargeGame())
+}
+
+func TestEncodeGameWithInvalidState(t *testing.T) {
+	game := NewStandardGame()
+	game.State = 6
+	if _, err := EncodeGame(game); !errors.Is(err, ErrInvalidState) {
+		t.Error("Expected", ErrInvalidState, "got", err)
+	}
+}
+
+func TestDecodeStoredValues(t *testing.T) {
+	midGame := &Game{}
+	midGame.UnmarshalText([]byte("xx1xx/5/3xo/n4/oo1oo 2n"))
+	storedValues := map[uint64]*Game{
+		//Stored before the version was introduced
+		2999468123231568: NewStandardGame(),
+		//Stored with version 1
+		12006667377972560: NewStandardGame(),
+		11865929543586898: midGame}
+
+	for value, referenceGame := range storedValues {
+		if result, message := Compare(referenceGame, UInt64ToGame(value)); !result {
+			t.Error("Stored value", value, "does not decode to the expected game: ", message)
+		}
+	}
+	if value := GameToUInt64(midGame); value != 11865929543586898 {
+		t.Error("Expected", uint64(11865929543586898), "got", value)
+	}
+}
+
+func TestSerializationDoesNotAllocate(t *testing.T) {
+	game := NewStandardGame()
+	intRepresentation := GameToUInt64(game)
+	target := &Game{}
+
+	if allocations := testing.AllocsPerRun(100, func() { GameToUInt64(game) }); allocations != 0 {
+		t.Error("Expected GameToUInt64 to not allocate, got", allocations)
+	}
+	if allocations := testing.AllocsPerRun(100, func() { UInt64IntoGame(intRepresentation, target) }); allocations != 0 {
+		t.Error("Expected UInt64IntoGame to not allocate, got", allocations)
+	}
+}
+
+func BenchmarkGameToUInt64(b *testing.B) {
+	game := NewStandardGame()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GameToUInt64(game)
+	}
+}
+
+func BenchmarkUInt64ToGame(b *testing.B) {
+	intRepresentation := GameToUInt64(NewStandardGame())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		UInt64ToGame(intRepresentation)
+	}
+}
+
+func BenchmarkUInt64IntoGame(b *testing.B) {
+	intRepresentation := GameToUInt64(NewStandardGame())
+	game := &Game{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		UInt64IntoGame(intRepresentation, game)
+	}
+}
@@ -4,7 +4,7 @@ package game
 * Basic setup of an empty game
 * Remember to add a neutrino to your game
 * otherwise it is invalid and the game controller
-* will panic
+* will panic. Use Validate to check the setup
  */
 func SetupEmptyGame() (*Game, *Controller) {
 	game := NewEmptyGame()
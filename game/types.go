@@ -2,13 +2,98 @@ package game
 
 import (
 	"errors"
+	"fmt"
 )
 
 //// Error type ////
 var (
 	ErrNoNeutrinoInGame = errors.New("Unable to locate neutrino")
+	ErrNothingToUndo    = errors.New("There are no moves to undo")
+	ErrNothingToRedo    = errors.New("There are no moves to redo")
+
+	ErrInvalidState             = errors.New("Invalid state")
+	ErrInvalidPosition          = errors.New("Invalid position")
+	ErrUnknownSerializerVersion = errors.New("Unknown serializer version")
+
+	ErrWrongTurn             = &MoveError{Reason: ReasonWrongTurn}
+	ErrGameOver              = &MoveError{Reason: ReasonGameOver}
+	ErrEmptySource           = &MoveError{Reason: ReasonEmptySource}
+	ErrNotStraightLine       = &MoveError{Reason: ReasonNotStraightLine}
+	ErrBlockedPath           = &MoveError{Reason: ReasonBlockedPath}
+	ErrDidNotSlideToObstacle = &MoveError{Reason: ReasonDidNotSlideToObstacle}
+	ErrHomeRowRestriction    = &MoveError{Reason: ReasonHomeRowRestriction}
+	ErrOutOfBounds           = &MoveError{Reason: ReasonOutOfBounds}
+	ErrNoMovement            = &MoveError{Reason: ReasonNoMovement}
+)
+
+//// MoveError type ////
+
+type MoveErrorReason byte
+
+const (
+	ReasonWrongTurn MoveErrorReason = iota
+	ReasonGameOver
+	ReasonEmptySource
+	ReasonNotStraightLine
+	ReasonBlockedPath
+	ReasonDidNotSlideToObstacle
+	ReasonHomeRowRestriction
+	ReasonOutOfBounds
+	ReasonNoMovement
 )
 
+var moveErrorMessages = map[MoveErrorReason]string{
+	ReasonWrongTurn:             "It is not the turn of the piece",
+	ReasonGameOver:              "Cannot move as the game has been won",
+	ReasonEmptySource:           "Move must start at a non empty board location",
+	ReasonNotStraightLine:       "Piece must be move in a straight line",
+	ReasonBlockedPath:           "Invalid move, cannot pass another piece",
+	ReasonDidNotSlideToObstacle: "Move does not move untill an obstacle is hit",
+	ReasonHomeRowRestriction:    "Cannot move all pieces back on home row",
+	ReasonOutOfBounds:           "Coordinates must be on the board",
+	ReasonNoMovement:            "The suggested move does not actually move any piece",
+}
+
+func (self MoveErrorReason) String() string {
+	if message, ok := moveErrorMessages[self]; ok {
+		return message
+	}
+	return fmt.Sprintf("Unknown move error %d", byte(self))
+}
+
+/**
+ * Error returned when a move is rejected. X and Y
+ * are the coordinates of the square that caused the
+ * move to be rejected, e.g. the blocking piece.
+ *
+ * Use errors.Is with one of the Err variables above
+ * to check the reason, or errors.As to get the details.
+ */
+type MoveError struct {
+	Reason MoveErrorReason
+	Move   Move
+	X, Y   byte
+}
+
+func newMoveError(reason MoveErrorReason, move Move, x, y byte) *MoveError {
+	return &MoveError{
+		Reason: reason,
+		Move:   move,
+		X:      x,
+		Y:      y,
+	}
+}
+
+func (self *MoveError) Error() string {
+	return fmt.Sprintf("%s. Move %v was rejected at (%d, %d)", self.Reason, self.Move, self.X, self.Y)
+}
+
+//Two move errors are the same if they have the same reason
+func (self *MoveError) Is(target error) bool {
+	other, ok := target.(*MoveError)
+	return ok && other.Reason == self.Reason
+}
+
 //// Move type ////
 
 type Move struct {
@@ -47,6 +132,8 @@ const (
 	W     Direction = 24
 )
 
+var directions = [8]Direction{N, NE, E, SE, S, SW, W, NW}
+
 const (
 	NorthOffset Direction = -10
 	SouthOffset Direction = 10
@@ -65,8 +152,55 @@ const (
 	Player2Move
 	Player1Win
 	Player2Win
+	//The states of the four player variant, player 3
+	//and 4 take their turns after player 1 and 2
+	Player3NeutrinoMove
+	Player3Move
+	Player4NeutrinoMove
+	Player4Move
+	Player3Win
+	Player4Win
 )
 
+//The player to move in the state, or the winner if the game is won
+func (self State) Player() Entry {
+	switch self {
+	case Player1NeutrinoMove, Player1Move, Player1Win:
+		return Player1
+	case Player2NeutrinoMove, Player2Move, Player2Win:
+		return Player2
+	case Player3NeutrinoMove, Player3Move, Player3Win:
+		return Player3
+	case Player4NeutrinoMove, Player4Move, Player4Win:
+		return Player4
+	default:
+		return EmptySquare
+	}
+}
+
+func (self State) IsWin() bool {
+	return self == Player1Win || self == Player2Win || self == Player3Win || self == Player4Win
+}
+
+func (self State) IsNeutrinoMove() bool {
+	return self == Player1NeutrinoMove || self == Player2NeutrinoMove || self == Player3NeutrinoMove || self == Player4NeutrinoMove
+}
+
+/**
+ * The states of a player, so the state machine
+ * can move on to the next player in the turn order
+ */
+type playerStates struct {
+	neutrinoMove, pieceMove, win State
+}
+
+var statesOfPlayer = [entryKinds]playerStates{
+	Player1: {Player1NeutrinoMove, Player1Move, Player1Win},
+	Player2: {Player2NeutrinoMove, Player2Move, Player2Win},
+	Player3: {Player3NeutrinoMove, Player3Move, Player3Win},
+	Player4: {Player4NeutrinoMove, Player4Move, Player4Win},
+}
+
 //// entry type ////
 
 type Entry byte
@@ -76,4 +210,20 @@ const (
 	Player1
 	Player2
 	Neutrino
+	Player3
+	Player4
+)
+
+//// WinReason type ////
+
+type WinReason byte
+
+const (
+	NoWinner WinReason = iota
+	//The neutrino was moved to a home row
+	HomeRowReached
+	//The loser could not move the neutrino
+	NeutrinoTrapped
+	//The loser could not move any piece after moving the neutrino
+	OpponentImmobilised
 )
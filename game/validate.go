@@ -0,0 +1,111 @@
+package game
+
+import "fmt"
+
+/**
+ * Checks the invariants the controller relies on, so
+ * positions set up by hand with SetLocation can be
+ * checked before they are played.
+ *
+ * The game must have exactly one neutrino, see
+ * ValidateFor for variants with several, at most as
+ * many pieces per player as the board is wide, only
+ * known entries and a known state. A won game must
+ * have the neutrino on the home row of the loser or a
+ * loser that cannot move, and a game that is not won
+ * must not have the neutrino on a home row.
+ */
+func (self *Game) Validate() error {
+	return self.ValidateFor(StandardRules())
+}
+
+/**
+ * Validates the game for a variant, the number of
+ * neutrinos and players must match the rules and moves
+ * are checked by the rules
+ */
+func (self *Game) ValidateFor(rules Rules) error {
+	players := rules.turnOrder()
+	if !containsPlayer(players, self.State.Player()) {
+		return fmt.Errorf("%w %d", ErrInvalidState, self.State)
+	}
+
+	counts := map[Entry]int{}
+	for y := byte(0); y < self.Height(); y++ {
+		for x := byte(0); x < self.Width(); x++ {
+			entry, _ := self.GetLocation(x, y)
+			if entry != EmptySquare && entry != Neutrino && !containsPlayer(players, entry) {
+				return fmt.Errorf("%w: unknown entry %d at (%d, %d)", ErrInvalidPosition, entry, x, y)
+			}
+			counts[entry]++
+		}
+	}
+
+	if counts[Neutrino] == 0 {
+		return ErrNoNeutrinoInGame
+	} else if counts[Neutrino] != rules.neutrinoCount() {
+		return fmt.Errorf("%w: expected %d neutrinos but found %d", ErrInvalidPosition, rules.neutrinoCount(), counts[Neutrino])
+	}
+	controller := &Controller{game: self, rules: rules}
+	for _, player := range players {
+		if counts[player] > int(controller.piecesPerPlayer(player)) {
+			return fmt.Errorf("%w: player %d has %d pieces, at most %d is allowed", ErrInvalidPosition, player, counts[player], controller.piecesPerPlayer(player))
+		}
+	}
+
+	return self.validateWinner(controller)
+}
+
+func (self *Game) validateWinner(controller *Controller) error {
+	neutrinos := controller.locateNeutrinos()
+	isWon := self.State.IsWin()
+
+	//The player a neutrino on a home edge has made win. In the
+	//misère variant it depends on who moved the neutrino there
+	edgeWinner := EmptySquare
+	for _, neutrino := range neutrinos {
+		if winner, ok := controller.getEdgeWinner(neutrino.x, neutrino.y); ok {
+			if edgeWinner != EmptySquare && winner != edgeWinner {
+				return fmt.Errorf("%w: neutrinos are on the home edges of different players", ErrInvalidPosition)
+			}
+			edgeWinner = winner
+		}
+	}
+
+	//With several neutrinos the loser can still move
+	//when one of them has been trapped
+	isTrapped := isWon && controller.isAnyNeutrinoTrapped()
+
+	switch {
+	case !isWon && self.WinReason != NoWinner:
+		return fmt.Errorf("%w: game has a win reason but no winner", ErrInvalidPosition)
+	case edgeWinner != EmptySquare && controller.rules.Misere && !isWon:
+		return fmt.Errorf("%w: neutrino is on a home edge but no player has won", ErrInvalidPosition)
+	case edgeWinner != EmptySquare && !controller.rules.Misere && self.State != statesOfPlayer[edgeWinner].win:
+		return fmt.Errorf("%w: neutrino is on a home edge but player %d has not won", ErrInvalidPosition, edgeWinner)
+	case isWon && edgeWinner == EmptySquare && !isTrapped:
+		//Losing by being immobilised hands the win
+		//to the next player
+		loser := controller.rules.previousPlayer(self.State.Player())
+		if self.canMove(controller.rules, statesOfPlayer[loser].neutrinoMove) && self.canMove(controller.rules, statesOfPlayer[loser].pieceMove) {
+			return fmt.Errorf("%w: player %d has won but no neutrino is on a home edge and player %d can move", ErrInvalidPosition, self.State.Player(), loser)
+		}
+	}
+	return nil
+}
+
+func containsPlayer(players []Entry, player Entry) bool {
+	for _, other := range players {
+		if other == player {
+			return true
+		}
+	}
+	return false
+}
+
+//Whether there is a legal move if the game was in the given state
+func (self *Game) canMove(rules Rules, state State) bool {
+	game := *self
+	game.State = state
+	return len((&Controller{game: &game, rules: rules}).legalMoves(true)) > 0
+}
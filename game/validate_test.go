@@ -0,0 +1,84 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateStandardGame(t *testing.T) {
+	if err := NewStandardGame().Validate(); err != nil {
+		t.Error("Expected the standard game to be valid, got", err)
+	}
+}
+
+func TestValidateRealGame(t *testing.T) {
+	game := NewStandardGame()
+	controller := &Controller{}
+	controller.PlayGame(game)
+
+	for _, move := range realGameMoves {
+		makeMoveAndCheckError(move.FromX, move.FromY, move.ToX, move.ToY, controller, t)
+		if err := game.Validate(); err != nil {
+			t.Error("Expected the game to be valid after", move, "got", err)
+		}
+	}
+}
+
+func TestValidateTrappedWin(t *testing.T) {
+	game, _ := SetupEmptyGame()
+
+	game.SetLocation(0, 0, Player1)
+	game.SetLocation(1, 0, Player1)
+	game.SetLocation(0, 2, Player2)
+	game.SetLocation(1, 2, Player2)
+	game.SetLocation(0, 1, Neutrino)
+	game.SetLocation(1, 1, Player1)
+	game.State = Player1Win
+
+	if err := game.Validate(); err != nil {
+		t.Error("Expected a trapped neutrino to be a valid win, got", err)
+	}
+}
+
+func TestValidateMissingNeutrino(t *testing.T) {
+	game, _ := SetupEmptyGame()
+	if err := game.Validate(); err != ErrNoNeutrinoInGame {
+		t.Error("Expected", ErrNoNeutrinoInGame, "got", err)
+	}
+}
+
+func TestValidateInvalidState(t *testing.T) {
+	game := NewStandardGame()
+	game.State = 6
+	if err := game.Validate(); !errors.Is(err, ErrInvalidState) {
+		t.Error("Expected", ErrInvalidState, "got", err)
+	}
+}
+
+func TestValidateInvalidPositions(t *testing.T) {
+	invalidPositions := map[string]string{
+		"Two neutrinos":                      "xxxxx/5/1n1n1/5/ooooo 1n",
+		"Six player 1 pieces":                "xxxxx/x4/2n2/5/ooooo 1n",
+		"Six player 2 pieces":                "xxxxx/5/2n2/4o/ooooo 2n",
+		"Neutrino on row 1 without a winner": "xnxxx/5/5/5/ooooo 1p",
+		"Neutrino on row 5 without a winner": "xxxxx/5/5/5/onooo 2p",
+		"Neutrino on row 5 for player 2":     "xxxxx/5/5/5/onooo 2w",
+		"Player 1 won without a reason":      "xxxxx/5/2n2/5/ooooo 1w",
+		"Player 2 won without a reason":      "xxxxx/5/2n2/5/ooooo 2w"}
+
+	for description, text := range invalidPositions {
+		game := &Game{}
+		if err := game.UnmarshalText([]byte(text)); err != nil {
+			t.Fatal("Could not set up", text, err)
+		}
+		if err := game.Validate(); !errors.Is(err, ErrInvalidPosition) {
+			t.Error(description, "expected", ErrInvalidPosition, "got", err)
+		}
+	}
+
+	game := NewStandardGame()
+	game.SetLocation(0, 2, 7)
+	if err := game.Validate(); !errors.Is(err, ErrInvalidPosition) {
+		t.Error("Unknown entry expected", ErrInvalidPosition, "got", err)
+	}
+}
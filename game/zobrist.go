@@ -0,0 +1,67 @@
+package game
+
+/**
+ * Zobrist hashing of a game. Every entry on every square
+ * has a random key and the hash of the board is the xor
+ * of the keys of the entries on it, so SetLocation can
+ * keep it up to date by xoring the keys of the old and
+ * the new entry. Hash adds keys for the state and the
+ * size of the board.
+ *
+ * The keys are generated from a fixed seed, so the hash
+ * of a game is the same between runs and can be stored,
+ * e.g. in an opening book.
+ */
+
+const zobristSeed = 0x6e657574726e6f21
+
+var (
+	zobristEntryKeys [entryKinds][maxSquares]uint64
+	zobristStateKeys [Player4Win + 1]uint64
+)
+
+func init() {
+	seed := uint64(zobristSeed)
+	//The empty square has no key so an empty board hashes to 0
+	for entry := Player1; entry < entryKinds; entry++ {
+		for square := range zobristEntryKeys[entry] {
+			zobristEntryKeys[entry][square] = splitMix64(&seed)
+		}
+	}
+	for state := range zobristStateKeys {
+		zobristStateKeys[state] = splitMix64(&seed)
+	}
+}
+
+/**
+ * A 64 bit hash of the board and the state of the game.
+ * Games that are equal by Compare have the same hash.
+ */
+func (self *Game) Hash() uint64 {
+	hash := self.hash
+	if int(self.State) < len(zobristStateKeys) {
+		hash ^= zobristStateKeys[self.State]
+	} else {
+		seed := uint64(self.State)
+		hash ^= splitMix64(&seed)
+	}
+	seed := uint64(self.Width())<<8 | uint64(self.Height())
+	return hash ^ splitMix64(&seed)
+}
+
+func zobristKey(entry Entry, square byte) uint64 {
+	if entry >= entryKinds {
+		//Unknown entries are hashed like the empty square
+		return 0
+	}
+	return zobristEntryKeys[entry][square]
+}
+
+//The splitmix64 generator, advancing the seed
+func splitMix64(seed *uint64) uint64 {
+	*seed += 0x9e3779b97f4a7c15
+	z := *seed
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
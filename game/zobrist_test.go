@@ -0,0 +1,96 @@
+package game
+
+import "testing"
+
+//The hash computed from scratch by setting every square on an empty game
+func hashFromScratch(game *Game) uint64 {
+	fresh, _ := NewEmptyGameOfSize(game.Width(), game.Height())
+	for y := byte(0); y < game.Height(); y++ {
+		for x := byte(0); x < game.Width(); x++ {
+			entry, _ := game.GetLocation(x, y)
+			fresh.SetLocation(x, y, entry)
+		}
+	}
+	fresh.State = game.State
+	return fresh.Hash()
+}
+
+func TestHashIsUpdatedByMakeMoveAndUndo(t *testing.T) {
+	game := NewStandardGame()
+	controller := NewController(StandardRules())
+	controller.PlayGame(game)
+	start := game.Hash()
+
+	hashes := []uint64{start}
+	for _, move := range realGameMoves {
+		makeMoveAndCheckError(move.FromX, move.FromY, move.ToX, move.ToY, controller, t)
+		if game.Hash() != hashFromScratch(game) {
+			t.Error("Expected the hash after", move, "to match the hash computed from scratch")
+		}
+		for _, hash := range hashes {
+			if hash == game.Hash() {
+				t.Error("Expected a new hash after", move)
+			}
+		}
+		hashes = append(hashes, game.Hash())
+	}
+
+	for controller.Undo() == nil {
+	}
+	if game.Hash() != start {
+		t.Error("Expected the hash of the standard game after undoing every move")
+	}
+}
+
+func TestHashOfTransposition(t *testing.T) {
+	game1, controller1 := SetupSquaredGame()
+	game2, controller2 := SetupSquaredGame()
+
+	//Moving two neutrinos in different orders, player 1 has
+	//no pieces so the state is reset after each move
+	makeMoveAndCheckError(1, 1, 0, 1, controller1, t)
+	game1.State = Player1NeutrinoMove
+	makeMoveAndCheckError(3, 3, 4, 3, controller1, t)
+	makeMoveAndCheckError(3, 3, 4, 3, controller2, t)
+	game2.State = Player1NeutrinoMove
+	makeMoveAndCheckError(1, 1, 0, 1, controller2, t)
+
+	if game1.Hash() != game2.Hash() {
+		t.Error("Expected the same position to have the same hash")
+	}
+}
+
+func TestHashIncludesState(t *testing.T) {
+	game := NewStandardGame()
+	hash := game.Hash()
+	game.State = Player2NeutrinoMove
+	if game.Hash() == hash {
+		t.Error("Expected the state to change the hash")
+	}
+}
+
+func TestHashIncludesSize(t *testing.T) {
+	game1, _ := NewEmptyGameOfSize(5, 7)
+	game2, _ := NewEmptyGameOfSize(7, 5)
+	if game1.Hash() == game2.Hash() {
+		t.Error("Expected games of different sizes to have different hashes")
+	}
+	if (&Game{}).Hash() != NewEmptyGame().Hash() {
+		t.Error("Expected the zero game to hash like the empty game")
+	}
+}
+
+func TestHashIsStable(t *testing.T) {
+	//The keys must not change as hashes may be stored
+	if hash := NewStandardGame().Hash(); hash != 10987870682176526662 {
+		t.Error("Expected the hash of the standard game to be", uint64(10987870682176526662), "got", hash)
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	game := NewStandardGame()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		game.Hash()
+	}
+}